@@ -5,249 +5,176 @@ import (
 	"fmt"
 	bookadd "utilodactyl/actions/books/add"
 	bookedit "utilodactyl/actions/books/edit"
+	bookprogress "utilodactyl/actions/books/progress"
 	bookpull "utilodactyl/actions/books/pull"
+	bookseries "utilodactyl/actions/books/series"
+	booktags "utilodactyl/actions/books/tags"
 	bookupdate "utilodactyl/actions/books/update"
 	bookview "utilodactyl/actions/books/view"
 	gameadd "utilodactyl/actions/games/add"
 	gameedit "utilodactyl/actions/games/edit"
 	gamepull "utilodactyl/actions/games/pull"
+	gamesession "utilodactyl/actions/games/session"
+	gametags "utilodactyl/actions/games/tags"
 	gameupdate "utilodactyl/actions/games/update"
 	gameview "utilodactyl/actions/games/view"
+	"utilodactyl/actions/outbox"
+	"utilodactyl/actions/people"
 	projectadd "utilodactyl/actions/projects/add"
 	projectedit "utilodactyl/actions/projects/edit"
 	projectpull "utilodactyl/actions/projects/pull"
+	projecttags "utilodactyl/actions/projects/tags"
 	projectupdate "utilodactyl/actions/projects/update"
 	projectview "utilodactyl/actions/projects/view"
 	reviewadd "utilodactyl/actions/reviews/add"
+	reviewarcs "utilodactyl/actions/reviews/arcs"
+	reviewbulk "utilodactyl/actions/reviews/bulk"
 	reviewedit "utilodactyl/actions/reviews/edit"
 	reviewpull "utilodactyl/actions/reviews/pull"
 	reviewupdate "utilodactyl/actions/reviews/update"
 	reviewview "utilodactyl/actions/reviews/view"
-
-	"github.com/charmbracelet/huh"
+	"utilodactyl/utils"
 )
 
 type AppAction string
 
 const (
-	PullAll        AppAction = "Get the latest releases"
-	Books          AppAction = "Operate on `books.json`"
-	Projects       AppAction = "Operate on `projects.json`"
-	Games          AppAction = "Operate on `games.json`"
-	Reviews        AppAction = "Operate on `reviews.json`"
-	AddBook        AppAction = "Add a new book"
-	AddProject     AppAction = "Add a new project"
-	AddGame        AppAction = "Add a new game"
-	AddReview      AppAction = "Add a new Chapter Review"
-	ViewBooks      AppAction = "View existing books"
-	ViewProject    AppAction = "View existing projects"
-	ViewGames      AppAction = "View existing games"
-	ViewReviews    AppAction = "View existing reviews"
-	EditBook       AppAction = "Edit a book"
-	EditProject    AppAction = "Edit a project"
-	EditGame       AppAction = "Edit a game"
-	EditReview     AppAction = "Edit a review"
-	UpdateBooks    AppAction = "Update the `books.json` release"
-	UpdateProjects AppAction = "Update the `projects.json` release"
-	UpdateGames    AppAction = "Update the `games.json` release"
-	UpdateReviews  AppAction = "Update the `reviews.json` release"
-	PullBooks      AppAction = "Pull the latest `books.json` release"
-	PullGames      AppAction = "Pull the latest `games.json` release"
-	PullProjects   AppAction = "Pull the latest `projects.json` release"
-	PullReviews    AppAction = "Pull the latest `reviews.json` release"
-	ExitApp        AppAction = "Exit"
+	PullAll           AppAction = "Get the latest releases"
+	Books             AppAction = "Operate on `books.json`"
+	Projects          AppAction = "Operate on `projects.json`"
+	Games             AppAction = "Operate on `games.json`"
+	Reviews           AppAction = "Operate on `reviews.json`"
+	AddBook           AppAction = "Add a new book"
+	AddProject        AppAction = "Add a new project"
+	AddGame           AppAction = "Add a new game"
+	AddReview         AppAction = "Add a new Chapter Review"
+	ViewBooks         AppAction = "View existing books"
+	ViewProject       AppAction = "View existing projects"
+	ViewGames         AppAction = "View existing games"
+	ViewReviews       AppAction = "View existing reviews"
+	EditBook          AppAction = "Edit a book"
+	EditProject       AppAction = "Edit a project"
+	EditGame          AppAction = "Edit a game"
+	EditReview        AppAction = "Edit a review"
+	UpdateBooks       AppAction = "Update the `books.json` release"
+	UpdateProjects    AppAction = "Update the `projects.json` release"
+	UpdateGames       AppAction = "Update the `games.json` release"
+	UpdateReviews     AppAction = "Update the `reviews.json` release"
+	PullBooks         AppAction = "Pull the latest `books.json` release"
+	PullGames         AppAction = "Pull the latest `games.json` release"
+	PullProjects      AppAction = "Pull the latest `projects.json` release"
+	PullReviews       AppAction = "Pull the latest `reviews.json` release"
+	LogBookProgress   AppAction = "Log reading progress"
+	LogGameSession    AppAction = "Log a play session"
+	ManageBookTags    AppAction = "Manage book genres and tags"
+	ManageBookSeries  AppAction = "Manage book series"
+	ManageProjectTags AppAction = "Manage project tags"
+	ManageGameTags    AppAction = "Manage game genres and tags"
+	ManagePeople      AppAction = "Manage people and studios"
+	ManageReviewArcs  AppAction = "Manage story arcs"
+	BulkAddReviews    AppAction = "Review a range of chapters"
+	SyncPending       AppAction = "Upload pending collections"
+	Back              AppAction = "Back"
+	ExitApp           AppAction = "Exit"
 )
 
+// App runs the interactive menus until the user quits.
 func App() error {
-	for {
-		var action AppAction
-		err := huh.NewSelect[AppAction]().
-			Title("What would you like to do?").
-			Options(
-				huh.NewOption(string(Books), Books),
-				huh.NewOption(string(Projects), Projects),
-				huh.NewOption(string(Games), Games),
-				huh.NewOption(string(Reviews), Reviews),
-				huh.NewOption(string(PullAll), PullAll),
-			).
-			Value(&action).Run()
-		if err != nil {
-			return fmt.Errorf("%w", err)
-		}
-
-		switch action {
-		case PullAll:
-			if err := bookpull.PullBooks(); err != nil {
-				fmt.Printf("Error pulling books.json: %v\n", err)
-			}
-			if err := projectpull.PullProjects(); err != nil {
-				fmt.Printf("Error pulling projects.json: %v\n", err)
-			}
-			if err := gamepull.PullGames(); err != nil {
-				fmt.Printf("Error pulling games.json: %v\n", err)
-			}
-			if err := reviewpull.PullReviews(); err != nil {
-				fmt.Printf("Error pulling reviews.json: %v\n", err)
-			}
-		case Books:
-			var bookAction AppAction
-			err := huh.NewSelect[AppAction]().
-				Title("What would you like to do?").
-				Options(
-					huh.NewOption(string(AddBook), AddBook),
-					huh.NewOption(string(ViewBooks), ViewBooks),
-					huh.NewOption(string(EditBook), EditBook),
-					huh.NewOption(string(PullBooks), PullBooks),
-					huh.NewOption(string(UpdateBooks), UpdateBooks),
-					huh.NewOption(string(ExitApp), ExitApp),
-				).
-				Value(&bookAction).
-				Run()
-			if err != nil {
-				return fmt.Errorf("%w", err)
-			}
+	return navigate(mainMenu)
+}
 
-			switch bookAction {
-			case AddBook:
-				if err := bookadd.AddBook(); err != nil {
-					fmt.Printf("Error adding book: %v\n", err)
-				}
-			case ViewBooks:
-				if err := bookview.ViewBooks(); err != nil {
-					fmt.Printf("Error viewing books: %v\n", err)
-				}
-			case EditBook:
-				if err := bookedit.EditBook(); err != nil {
-					fmt.Printf("Error editing book: %v\n", err)
-				}
-			case UpdateBooks:
-				if err := bookupdate.UpdateBooks(); err != nil {
-					fmt.Printf("Error updating books: %v\n", err)
-				}
-			case PullBooks:
-				if err := bookpull.PullBooks(); err != nil {
-					fmt.Printf("Error pulling books.json: %v\n", err)
-				}
-			}
-		case Projects:
-			var projectAction AppAction
-			err := huh.NewSelect[AppAction]().
-				Title("What would you like to do?").
-				Options(
-					huh.NewOption(string(AddProject), AddProject),
-					huh.NewOption(string(EditProject), EditProject),
-					huh.NewOption(string(ViewProject), ViewProject),
-					huh.NewOption(string(UpdateProjects), UpdateProjects),
-					huh.NewOption(string(PullProjects), PullProjects),
-				).
-				Value(&projectAction).
-				Run()
-			if err != nil {
-				return fmt.Errorf("%w", err)
-			}
+func mainMenu() *menu {
+	m := &menu{
+		name: "utilodactyl",
+		note: utils.PendingUploadsBanner(),
+		items: []menuItem{
+			{action: Books, submenu: booksMenu},
+			{action: Projects, submenu: projectsMenu},
+			{action: Games, submenu: gamesMenu},
+			{action: Reviews, submenu: reviewsMenu},
+			{action: ManagePeople, run: people.ManagePeople, doing: "managing people"},
+			{action: PullAll, run: pullAll, doing: "pulling releases"},
+		},
+	}
+	if m.note != "" {
+		m.items = append(m.items, menuItem{action: SyncPending, run: outbox.Sync, doing: "syncing pending uploads"})
+	}
+	return m
+}
 
-			switch projectAction {
-			case AddProject:
-				if err := projectadd.AddProject(); err != nil {
-					fmt.Printf("Error adding project: %v\n", err)
-				}
-			case EditProject:
-				if err := projectedit.EditProject(); err != nil {
-					fmt.Printf("Error editing project: %v\n", err)
-				}
-			case ViewProject:
-				if err := projectview.ViewProjects(); err != nil {
-					fmt.Printf("Error viewing projects: %v\n", err)
-				}
-			case UpdateProjects:
-				if err := projectupdate.UpdateProjects(); err != nil {
-					fmt.Printf("Error updating projects: %v\n", err)
-				}
-			case PullProjects:
-				if err := projectpull.PullProjects(); err != nil {
-					fmt.Printf("Error pulling projects.json: %v\n", err)
-				}
-			}
-		case Games:
-			var gamesAction AppAction
-			err := huh.NewSelect[AppAction]().
-				Title("What would you like to do?").
-				Options(
-					huh.NewOption(string(AddGame), AddGame),
-					huh.NewOption(string(EditGame), EditGame),
-					huh.NewOption(string(PullGames), PullGames),
-					huh.NewOption(string(UpdateGames), UpdateGames),
-					huh.NewOption(string(ViewGames), ViewGames),
-				).
-				Value(&gamesAction).
-				Run()
-			if err != nil {
-				return fmt.Errorf("%w", err)
-			}
+// pullAll pulls every collection, reporting each failure without stopping.
+func pullAll() error {
+	if err := bookpull.PullBooks(); err != nil {
+		fmt.Printf("Error pulling books.json: %v\n", err)
+	}
+	if err := projectpull.PullProjects(); err != nil {
+		fmt.Printf("Error pulling projects.json: %v\n", err)
+	}
+	if err := gamepull.PullGames(); err != nil {
+		fmt.Printf("Error pulling games.json: %v\n", err)
+	}
+	if err := reviewpull.PullReviews(); err != nil {
+		fmt.Printf("Error pulling reviews.json: %v\n", err)
+	}
+	return nil
+}
 
-			switch gamesAction {
-			case AddGame:
-				if err := gameadd.AddGame(); err != nil {
-					fmt.Printf("Error adding game: %v\n", err)
-				}
-			case EditGame:
-				if err := gameedit.EditGame(); err != nil {
-					fmt.Printf("Error editing game: %v\n", err)
-				}
-			case UpdateGames:
-				if err := gameupdate.UpdateGames(); err != nil {
-					fmt.Printf("Error updating games: %v\n", err)
-				}
-			case ViewGames:
-				if err := gameview.ViewGames(); err != nil {
-					fmt.Printf("Error viewing games: %v\n", err)
-				}
-			case PullGames:
-				if err := gamepull.PullGames(); err != nil {
-					fmt.Printf("Error pulling games.json: %v\n", err)
-				}
-			}
-		case Reviews:
-			var reviewAction AppAction
-			err := huh.NewSelect[AppAction]().
-				Title("What would you like to do?").
-				Options(
-					huh.NewOption(string(AddReview), AddReview),
-					huh.NewOption(string(EditReview), EditReview),
-					huh.NewOption(string(PullReviews), PullReviews),
-					huh.NewOption(string(UpdateReviews), UpdateReviews),
-					huh.NewOption(string(ViewReviews), ViewReviews),
-				).
-				Value(&reviewAction).
-				Run()
-			if err != nil {
-				return fmt.Errorf("%w", err)
-			}
+func booksMenu() *menu {
+	return &menu{
+		name: "Books",
+		items: []menuItem{
+			{action: AddBook, run: bookadd.AddBook, doing: "adding book"},
+			{action: ViewBooks, run: bookview.ViewBooks, doing: "viewing books"},
+			{action: LogBookProgress, run: bookprogress.LogProgress, doing: "logging reading progress"},
+			{action: EditBook, run: bookedit.EditBook, doing: "editing book"},
+			{action: ManageBookTags, run: booktags.ManageBookTags, doing: "managing book tags"},
+			{action: ManageBookSeries, run: bookseries.ManageSeries, doing: "managing book series"},
+			{action: PullBooks, run: bookpull.PullBooks, doing: "pulling books.json"},
+			{action: UpdateBooks, run: bookupdate.UpdateBooks, doing: "updating books"},
+		},
+	}
+}
 
-			switch reviewAction {
-			case AddReview:
-				if err := reviewadd.AddReview(); err != nil {
-					fmt.Printf("Error adding review: %v\n", err)
-				}
-			case EditReview:
-				if err := reviewedit.EditReview(); err != nil {
-					fmt.Printf("Error editing review: %v\n", err)
-				}
-			case UpdateReviews:
-				if err := reviewupdate.UpdateReviews(); err != nil {
-					fmt.Printf("Error updating review: %v\n", err)
-				}
-			case ViewReviews:
-				if err := reviewview.ViewReviews(); err != nil {
-					fmt.Printf("Error viewing review: %v\n", err)
-				}
-			case PullReviews:
-				if err := reviewpull.PullReviews(); err != nil {
-					fmt.Printf("Error pulling reviews.json: %v\n", err)
-				}
-			}
+func projectsMenu() *menu {
+	return &menu{
+		name: "Projects",
+		items: []menuItem{
+			{action: AddProject, run: projectadd.AddProject, doing: "adding project"},
+			{action: EditProject, run: projectedit.EditProject, doing: "editing project"},
+			{action: ManageProjectTags, run: projecttags.ManageProjectTags, doing: "managing project tags"},
+			{action: ViewProject, run: projectview.ViewProjects, doing: "viewing projects"},
+			{action: UpdateProjects, run: projectupdate.UpdateProjects, doing: "updating projects"},
+			{action: PullProjects, run: projectpull.PullProjects, doing: "pulling projects.json"},
+		},
+	}
+}
 
-		}
+func gamesMenu() *menu {
+	return &menu{
+		name: "Games",
+		items: []menuItem{
+			{action: AddGame, run: gameadd.AddGame, doing: "adding game"},
+			{action: LogGameSession, run: gamesession.LogSession, doing: "logging play session"},
+			{action: EditGame, run: gameedit.EditGame, doing: "editing game"},
+			{action: ManageGameTags, run: gametags.ManageGameTags, doing: "managing game tags"},
+			{action: PullGames, run: gamepull.PullGames, doing: "pulling games.json"},
+			{action: UpdateGames, run: gameupdate.UpdateGames, doing: "updating games"},
+			{action: ViewGames, run: gameview.ViewGames, doing: "viewing games"},
+		},
+	}
+}
 
+func reviewsMenu() *menu {
+	return &menu{
+		name: "Reviews",
+		items: []menuItem{
+			{action: AddReview, run: reviewadd.AddReview, doing: "adding review"},
+			{action: BulkAddReviews, run: reviewbulk.BulkAddReviews, doing: "reviewing a range of chapters"},
+			{action: EditReview, run: reviewedit.EditReview, doing: "editing review"},
+			{action: ManageReviewArcs, run: reviewarcs.ManageArcs, doing: "managing story arcs"},
+			{action: PullReviews, run: reviewpull.PullReviews, doing: "pulling reviews.json"},
+			{action: UpdateReviews, run: reviewupdate.UpdateReviews, doing: "updating review"},
+			{action: ViewReviews, run: reviewview.ViewReviews, doing: "viewing review"},
+		},
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"utilodactyl/models"
+	"utilodactyl/ui/forms"
 	"utilodactyl/utils"
 
 	"github.com/charmbracelet/huh"
@@ -16,9 +17,44 @@ func AddBook() error {
 		return fmt.Errorf("failed to load books: %v", err)
 	}
 
+	statuses, err := utils.BookStatuses()
+	if err != nil {
+		return fmt.Errorf("failed to load book statuses: %w", err)
+	}
+
+	scale, err := utils.BookScale()
+	if err != nil {
+		return fmt.Errorf("failed to load book rating scale: %w", err)
+	}
+
+	cfg, err := utils.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	taxonomy, err := utils.BookWarnings()
+	if err != nil {
+		return fmt.Errorf("failed to load book content warnings: %w", err)
+	}
+
+	vocab, err := utils.LoadVocabulary(utils.BookVocabularyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load book vocabulary: %w", err)
+	}
+
+	series, err := utils.LoadSeries()
+	if err != nil {
+		return fmt.Errorf("failed to load series: %w", err)
+	}
+
+	people, err := utils.LoadPeople()
+	if err != nil {
+		return fmt.Errorf("failed to load people: %w", err)
+	}
+
 	var newBook models.Book
-	var rating int
-	var status string
+	var rating models.Rating
+	var status models.Status
 
 	basicDetailsGroup := huh.NewGroup(
 		huh.NewInput().Title("Title:").Value(&newBook.Title).Validate(func(s string) error {
@@ -29,20 +65,6 @@ func AddBook() error {
 			return nil
 		},
 		),
-		huh.NewInput().
-			Title("Author:").
-			Value(&newBook.Author).
-			Validate(func(s string) error {
-				if strings.TrimSpace(s) == "" {
-					return fmt.Errorf("author cannot be empty")
-				}
-				return nil
-			}),
-
-		huh.NewConfirm().
-			Title("Explicit Content:").
-			Value(&newBook.Explicit),
-
 		huh.NewInput().
 			Title("Cover Image URL:").
 			Value(&newBook.CoverImage).
@@ -67,26 +89,9 @@ func AddBook() error {
 				return nil
 			}),
 
-		huh.NewSelect[int]().
-			Title("Rating (1-5):").
-			Options(
-				huh.NewOption("1", 1),
-				huh.NewOption("2", 2),
-				huh.NewOption("3", 3),
-				huh.NewOption("4", 4),
-				huh.NewOption("5", 5),
-			).
-			Value(&rating),
+		forms.RatingField(scale, &rating),
 
-		huh.NewSelect[string]().
-			Title("Reading Status:").
-			Options(
-				huh.NewOption("Reading", "Reading"),
-				huh.NewOption("Finished", "Finished"),
-				huh.NewOption("Plan to Read", "Plan to Read"),
-				huh.NewOption("Dropped", "Dropped"),
-			).
-			Value(&status),
+		forms.StatusSelect("Reading Status:", statuses, "", &status),
 		huh.NewInput().
 			Title("Border Color:").
 			Value(&newBook.Color).
@@ -99,207 +104,64 @@ func AddBook() error {
 			}),
 	)
 
-	if err = huh.NewForm(basicDetailsGroup).Run(); err != nil {
-		return fmt.Errorf("form input error for basic book details: %w", err)
-	}
-
-	newBook.Rating = uint16(rating)
-	newBook.Status = status
-
-	if err = handleGenres(books, &newBook); err != nil {
-		return fmt.Errorf("error handling book genres: %w", err)
+	groups := []*huh.Group{
+		basicDetailsGroup,
+		forms.BookProgressGroup(&newBook),
+		forms.BookSeriesGroup(&newBook, series, books),
 	}
-
-	if err = handleTags(books, &newBook); err != nil {
-		return fmt.Errorf("error handling book tags: %w", err)
+	groups = append(groups, forms.ContentWarningGroups(taxonomy, &newBook.ContentWarnings, &newBook.Explicit)...)
+	groups = append(groups, forms.PrivacyGroups(utils.BookPrivateFields, cfg.Books.PrivateFields, &newBook.Private, &newBook.PrivateFields)...)
+	groups = append(groups, forms.ScheduleGroup(&newBook.Draft, &newBook.PublishAt))
+	err = forms.NewForm(groups...).Run()
+	if err != nil {
+		return fmt.Errorf("form input error for basic book details: %w", err)
 	}
 
-	if err = handleLinks(&newBook); err != nil {
-		return fmt.Errorf("error handling book links: %w", err)
+	newBook.Rating = rating
+	newBook.Explicit = utils.DeriveExplicit(taxonomy, newBook.ContentWarnings, newBook.Explicit)
+	if newBook.SeriesID == 0 {
+		newBook.SeriesPosition = 0
 	}
-
-	newBook.ID, err = utils.GenerateBookID()
+	reason, err := forms.AskStatusReason(statuses, status)
 	if err != nil {
-		return fmt.Errorf("failed to generate unique book ID: %w", err)
+		return fmt.Errorf("form input error for book status: %w", err)
 	}
-
-	books = append(books, newBook)
-
-	if err = utils.SaveBooks(books); err != nil {
-		return fmt.Errorf("failed to save books after adding new entry: %w", err)
+	if err = utils.ChangeBookStatus(statuses, &newBook, status, reason); err != nil {
+		return err
 	}
 
-	fmt.Println("✅. Book added successfully!")
-	return nil
-}
-
-func handleGenres(existingBooks []models.Book, book *models.Book) error {
-	existingGenres := utils.CollectUniqueBookGenres(existingBooks)
-	if len(existingGenres) > 0 {
-		err := huh.NewMultiSelect[string]().
-			Title("Select existing genres:").
-			Options(huh.NewOptions(existingGenres...)...).
-			Value(&book.Genres).
-			Height(10).
-			Run()
-		if err != nil {
-			return err
-		}
+	if newBook.Contributors, err = forms.EditContributors(&people, nil, utils.BookRoles); err != nil {
+		return fmt.Errorf("error handling book contributors: %w", err)
 	}
+	utils.LinkBookPeople(&people, &newBook)
 
-	var confirmAddGenre bool
-	err := huh.NewConfirm().
-		Title("Add custom genres?").
-		Value(&confirmAddGenre).
-		Run()
+	err = forms.EditStringSets(
+		forms.NewStringSet("genres", utils.CollectUniqueBookGenres(books), &newBook.Genres).WithVocabulary(vocab.Genres),
+		forms.NewStringSet("tags", utils.CollectUniqueBookTags(books), &newBook.Tags).WithVocabulary(vocab.Tags),
+	)
 	if err != nil {
-		return err
-	}
-
-	if confirmAddGenre {
-		for {
-			var customGenre string
-			err = huh.NewInput().
-				Title("New genre:").
-				Value(&customGenre).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("genre cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			book.Genres = append(book.Genres, customGenre)
-
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another genre?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
+		return fmt.Errorf("error handling book genres and tags: %w", err)
 	}
-	return nil
-}
 
-func handleTags(existingBooks []models.Book, book *models.Book) error {
-	existingTags := utils.CollectUniqueBookTags(existingBooks)
-	if len(existingTags) > 0 {
-		err := huh.NewMultiSelect[string]().
-			Title("Select existing tags:").
-			Options(huh.NewOptions(existingTags...)...).
-			Value(&book.Tags).
-			Height(10).
-			Run()
-		if err != nil {
-			return err
-		}
+	if newBook.Links, err = forms.EditLinks(nil); err != nil {
+		return fmt.Errorf("error handling book links: %w", err)
 	}
 
-	var confirmAddTag bool
-	err := huh.NewConfirm().
-		Title("Add custom tags?").
-		Value(&confirmAddTag).
-		Run()
+	newBook.ID, err = utils.GenerateBookID()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to generate unique book ID: %w", err)
 	}
 
-	if confirmAddTag {
-		for {
-			var customTag string
-			err = huh.NewInput().
-				Title("New tag:").
-				Value(&customTag).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("tag cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			book.Tags = append(book.Tags, customTag)
+	books = append(books, newBook)
 
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another tag?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
+	if err = utils.SavePeople(people); err != nil {
+		return fmt.Errorf("failed to save people: %w", err)
 	}
-	return nil
-}
 
-func handleLinks(book *models.Book) error {
-	var confirmAddLink bool
-	err := huh.NewConfirm().
-		Title("Add links?").
-		Value(&confirmAddLink).
-		Run()
-	if err != nil {
-		return err
+	if err = utils.SaveBooks(books); err != nil {
+		return fmt.Errorf("failed to save books after adding new entry: %w", err)
 	}
 
-	if confirmAddLink {
-		for {
-			var linkTitle, linkURL string
-			err = huh.NewInput().
-				Title("Link title:").
-				Value(&linkTitle).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("link title cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			err = huh.NewInput().
-				Title("Link URL:").
-				Value(&linkURL).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("link URL cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			book.Links = append(book.Links, models.ItemLink{Title: linkTitle, URL: linkURL})
-
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another link?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
-	}
+	fmt.Println("✅. Book added successfully!")
 	return nil
 }
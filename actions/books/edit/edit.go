@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"utilodactyl/models"
+	"utilodactyl/ui/forms"
 	"utilodactyl/utils"
 
 	"github.com/charmbracelet/huh"
@@ -23,18 +24,23 @@ func EditBook() error {
 		return nil
 	}
 
-	// Prepare options for selecting a book by title.
-	bookTitles := make([]string, len(books))
-	for i, b := range books {
-		bookTitles[i] = b.Title
+	candidates, err := forms.FilterPrompt(books, "books")
+	if err != nil {
+		return fmt.Errorf("book filter cancelled or failed: %w", err)
+	}
+
+	// Books are picked by ID, since titles are not unique (e.g. a hardcover
+	// and an audiobook edition of the same book).
+	bookOptions := make([]huh.Option[uint32], len(candidates))
+	for i, b := range candidates {
+		bookOptions[i] = huh.NewOption(utils.BookLabel(b), b.ID)
 	}
 
-	var selectedTitle string
-	err = huh.NewSelect[string]().
+	var selectedID uint32
+	err = forms.Run(huh.NewSelect[uint32]().
 		Title("Choose a book to edit:").
-		Options(huh.NewOptions(bookTitles...)...).
-		Value(&selectedTitle).
-		Run()
+		Options(bookOptions...).
+		Value(&selectedID))
 	if err != nil {
 		return fmt.Errorf("book selection cancelled or failed: %w", err)
 	}
@@ -42,22 +48,78 @@ func EditBook() error {
 	// Find the selected book. We use a pointer to modify the book directly in the slice.
 	var bookToEdit *models.Book
 	for i := range books {
-		if books[i].Title == selectedTitle {
+		if books[i].ID == selectedID {
 			bookToEdit = &books[i]
 			break
 		}
 	}
 
 	if bookToEdit == nil {
-		return fmt.Errorf("internal error: selected book '%s' not found", selectedTitle)
+		return fmt.Errorf("internal error: selected book %d not found", selectedID)
+	}
+
+	return editBook(books, bookToEdit)
+}
+
+// EditBookByID opens the edit form for the book with the given ID.
+func EditBookByID(id uint32) error {
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return fmt.Errorf("failed to load books for editing: %w", err)
+	}
+
+	for i := range books {
+		if books[i].ID == id {
+			return editBook(books, &books[i])
+		}
+	}
+	return fmt.Errorf("book with id %d not found", id)
+}
+
+// editBook runs the edit forms for bookToEdit, which must point into books,
+// and saves the updated list.
+func editBook(books []models.Book, bookToEdit *models.Book) error {
+	statuses, err := utils.BookStatuses()
+	if err != nil {
+		return fmt.Errorf("failed to load book statuses: %w", err)
+	}
+
+	scale, err := utils.BookScale()
+	if err != nil {
+		return fmt.Errorf("failed to load book rating scale: %w", err)
+	}
+
+	cfg, err := utils.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	taxonomy, err := utils.BookWarnings()
+	if err != nil {
+		return fmt.Errorf("failed to load book content warnings: %w", err)
+	}
+
+	vocab, err := utils.LoadVocabulary(utils.BookVocabularyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load book vocabulary: %w", err)
+	}
+
+	series, err := utils.LoadSeries()
+	if err != nil {
+		return fmt.Errorf("failed to load series: %w", err)
+	}
+
+	people, err := utils.LoadPeople()
+	if err != nil {
+		return fmt.Errorf("failed to load people: %w", err)
 	}
 
 	// Temporary variables to hold form input values.
-	rating := int(bookToEdit.Rating)
+	rating := bookToEdit.Rating
 	status := bookToEdit.Status
 
 	// Define the form for editing basic book details.
-	basicDetailsForm := huh.NewForm(
+	groups := []*huh.Group{
 		huh.NewGroup(
 			huh.NewInput().
 				Title("Title:").
@@ -68,27 +130,10 @@ func EditBook() error {
 					}
 					return nil
 				}),
-			huh.NewInput().
-				Title("Author:").
-				Value(&bookToEdit.Author).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("author cannot be empty")
-					}
-					return nil
-				}),
-			huh.NewConfirm().
-				Title("Explicit Content:").
-				Value(&bookToEdit.Explicit),
 			huh.NewInput().
 				Title("Cover Image URL:").
 				Value(&bookToEdit.CoverImage).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("cover image URL cannot be empty")
-					}
-					return nil
-				}),
+				Validate(utils.ValidateURL),
 			huh.NewText().
 				Title("Description:").
 				Value(&bookToEdit.Description).
@@ -107,242 +152,79 @@ func EditBook() error {
 					}
 					return nil
 				}),
-			huh.NewSelect[int]().
-				Title("Rating (1–5):").
-				Options(
-					huh.NewOption("1", 1),
-					huh.NewOption("2", 2),
-					huh.NewOption("3", 3),
-					huh.NewOption("4", 4),
-					huh.NewOption("5", 5),
-				).
-				Value(&rating),
-			huh.NewSelect[string]().
-				Title("Reading Status:").
-				Options(
-					huh.NewOption("Reading", "Reading"),
-					huh.NewOption("Finished", "Finished"),
-					huh.NewOption("Plan to Read", "Plan to Read"),
-					huh.NewOption("Dropped", "Dropped"),
-				).
-				Value(&status),
+			forms.RatingField(scale, &rating),
+			forms.StatusSelect("Reading Status:", statuses, bookToEdit.Status, &status),
 			huh.NewInput().
 				Title("Border Color:").
 				Value(&bookToEdit.Color).
 				Validate(utils.ValidateColor),
 		),
-	)
+		forms.BookProgressGroup(bookToEdit),
+		forms.BookSeriesGroup(bookToEdit, series, books),
+	}
+	groups = append(groups, forms.ContentWarningGroups(taxonomy, &bookToEdit.ContentWarnings, &bookToEdit.Explicit)...)
+	groups = append(groups, forms.PrivacyGroups(utils.BookPrivateFields, cfg.Books.PrivateFields, &bookToEdit.Private, &bookToEdit.PrivateFields)...)
+	groups = append(groups, forms.ScheduleGroup(&bookToEdit.Draft, &bookToEdit.PublishAt))
+	basicDetailsForm := forms.NewForm(groups...)
 
 	// Run the basic details form.
-	if err = basicDetailsForm.Run(); err != nil {
+	if err := basicDetailsForm.Run(); err != nil {
 		return fmt.Errorf("form input error for book details: %w", err)
 	}
 
-	bookToEdit.Rating = uint16(rating) // Update the book's rating.
-	bookToEdit.Status = status         // Update the book's status.
-
-	// Handle genre modifications.
-	if err = editGenres(books, bookToEdit); err != nil {
-		return fmt.Errorf("error editing book genres: %w", err)
-	}
-
-	// Handle tag modifications.
-	if err = editTags(books, bookToEdit); err != nil {
-		return fmt.Errorf("error editing book tags: %w", err)
+	bookToEdit.Rating = rating // Update the book's rating.
+	bookToEdit.Explicit = utils.DeriveExplicit(taxonomy, bookToEdit.ContentWarnings, bookToEdit.Explicit)
+	if bookToEdit.SeriesID == 0 {
+		bookToEdit.SeriesPosition = 0
 	}
 
-	// Handle link modifications (allowing add/remove or edit existing).
-	// For simplicity, current implementation re-prompts to add new links.
-	// A more robust solution might allow selecting and editing/deleting existing links.
-	if err = editLinks(bookToEdit); err != nil {
-		return fmt.Errorf("error editing book links: %w", err)
-	}
-
-	// Save the updated list of books back to storage.
-	if err = utils.SaveBooks(books); err != nil {
-		return fmt.Errorf("failed to save books after editing: %w", err)
-	}
-
-	fmt.Println("✅ Book updated successfully!")
-	return nil
-}
-
-// editGenres allows the user to select/deselect existing genres and add new custom ones.
-func editGenres(allBooks []models.Book, book *models.Book) error {
-	existingGenres := utils.CollectUniqueBookGenres(allBooks)
-	if len(existingGenres) > 0 {
-		// Pre-select current genres for the book in the multi-select.
-		selectedGenres := book.Genres
-		err := huh.NewMultiSelect[string]().
-			Title("Select/Deselect existing genres:").
-			Options(huh.NewOptions(existingGenres...)...).
-			Value(&selectedGenres).
-			Run()
+	// Update the book's status, applying the workflow's side effects.
+	if status != bookToEdit.Status {
+		reason, err := forms.AskStatusReason(statuses, status)
 		if err != nil {
+			return fmt.Errorf("form input error for book status: %w", err)
+		}
+		if err := utils.ChangeBookStatus(statuses, bookToEdit, status, reason); err != nil {
 			return err
 		}
-		book.Genres = selectedGenres // Update the book's genres.
 	}
 
-	var confirmAddGenre bool
-	err := huh.NewConfirm().
-		Title("Add custom genres?").
-		Value(&confirmAddGenre).
-		Run()
+	// Credit the authors and other contributors, starting from the free-text
+	// author of books entered before the people registry.
+	utils.LinkBookPeople(&people, bookToEdit)
+	contributors, err := forms.EditContributors(&people, bookToEdit.Contributors, utils.BookRoles)
 	if err != nil {
-		return err
-	}
-
-	if confirmAddGenre {
-		for {
-			var customGenre string
-			err = huh.NewInput().
-				Title("New genre:").
-				Value(&customGenre).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("genre cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			book.Genres = append(book.Genres, customGenre)
-
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another genre?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
+		return fmt.Errorf("error editing book contributors: %w", err)
 	}
-	return nil
-}
+	bookToEdit.Contributors = contributors
+	utils.LinkBookPeople(&people, bookToEdit)
 
-// editTags allows the user to select/deselect existing tags and add new custom ones.
-func editTags(allBooks []models.Book, book *models.Book) error {
-	existingTags := utils.CollectUniqueBookTags(allBooks)
-	if len(existingTags) > 0 {
-		// Pre-select current tags for the book in the multi-select.
-		selectedTags := book.Tags
-		err := huh.NewMultiSelect[string]().
-			Title("Select/Deselect existing tags:").
-			Options(huh.NewOptions(existingTags...)...).
-			Value(&selectedTags).
-			Run()
-		if err != nil {
-			return err
-		}
-		book.Tags = selectedTags // Update the book's tags.
+	// Handle genre and tag modifications.
+	err = forms.EditStringSets(
+		forms.NewStringSet("genres", utils.CollectUniqueBookGenres(books), &bookToEdit.Genres).WithVocabulary(vocab.Genres),
+		forms.NewStringSet("tags", utils.CollectUniqueBookTags(books), &bookToEdit.Tags).WithVocabulary(vocab.Tags),
+	)
+	if err != nil {
+		return fmt.Errorf("error editing book genres and tags: %w", err)
 	}
 
-	var confirmAddTag bool
-	err := huh.NewConfirm().
-		Title("Add custom tags?").
-		Value(&confirmAddTag).
-		Run()
+	// Handle link modifications: add, edit, delete and reorder links.
+	links, err := forms.EditLinks(bookToEdit.Links)
 	if err != nil {
-		return err
+		return fmt.Errorf("error editing book links: %w", err)
 	}
+	bookToEdit.Links = links
 
-	if confirmAddTag {
-		for {
-			var customTag string
-			err = huh.NewInput().
-				Title("New tag:").
-				Value(&customTag).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("tag cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			book.Tags = append(book.Tags, customTag)
-
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another tag?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
+	// Save the people registry first, since the books refer to it.
+	if err := utils.SavePeople(people); err != nil {
+		return fmt.Errorf("failed to save people: %w", err)
 	}
-	return nil
-}
 
-// editLinks allows adding new links to a book.
-// Note: This current implementation only adds new links. To allow full editing (modify/delete),
-// a more complex form flow would be needed (e.g., display existing links and allow selecting to edit/delete).
-func editLinks(book *models.Book) error {
-	var confirmAddLink bool
-	err := huh.NewConfirm().
-		Title("Add more links?").
-		Value(&confirmAddLink).
-		Run()
-	if err != nil {
-		return err
+	// Save the updated list of books back to storage.
+	if err := utils.SaveBooks(books); err != nil {
+		return fmt.Errorf("failed to save books after editing: %w", err)
 	}
 
-	if confirmAddLink {
-		for {
-			var linkTitle, linkURL string
-			err = huh.NewInput().
-				Title("Link title:").
-				Value(&linkTitle).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("link title cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			err = huh.NewInput().
-				Title("Link URL:").
-				Value(&linkURL).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("link URL cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			book.Links = append(book.Links, models.ItemLink{Title: linkTitle, URL: linkURL})
-
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another link?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
-	}
+	fmt.Println("✅ Book updated successfully!")
 	return nil
 }
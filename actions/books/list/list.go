@@ -0,0 +1,41 @@
+// Package list
+package list
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"utilodactyl/query"
+	"utilodactyl/utils"
+)
+
+// ListBooks prints the books matching expr, one per line. An empty expr lists
+// every book.
+func ListBooks(expr string) error {
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return fmt.Errorf("failed to load books: %w", err)
+	}
+
+	books, err = query.Filter(books, expr)
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	if len(books) == 0 {
+		fmt.Println("No matching books.")
+		return nil
+	}
+
+	scale, err := utils.BookScale()
+	if err != nil {
+		return fmt.Errorf("failed to load book rating scale: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTITLE\tAUTHOR\tRATING\tSTATUS\tPROGRESS")
+	for _, b := range books {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", b.ID, b.Title, b.Author, scale.Format(b.Rating), b.Status, utils.FormatBookProgress(b))
+	}
+	return w.Flush()
+}
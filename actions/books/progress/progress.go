@@ -0,0 +1,137 @@
+package progress
+
+import (
+	"fmt"
+	"utilodactyl/models"
+	"utilodactyl/ui/forms"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// LogProgress updates how far the user is through a book without going
+// through the whole edit form. Books being read are offered first, and
+// reaching the end offers to move the book to a completing status.
+func LogProgress() error {
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return fmt.Errorf("failed to load books: %w", err)
+	}
+	statuses, err := utils.BookStatuses()
+	if err != nil {
+		return fmt.Errorf("failed to load book statuses: %w", err)
+	}
+
+	if len(books) == 0 {
+		fmt.Println("No books available.")
+		return nil
+	}
+
+	candidates, err := forms.FilterPrompt(inProgressFirst(books, statuses), "books")
+	if err != nil {
+		return fmt.Errorf("book filter cancelled or failed: %w", err)
+	}
+
+	options := make([]huh.Option[uint32], len(candidates))
+	for i, b := range candidates {
+		label := utils.BookLabel(b)
+		if p := utils.FormatBookProgress(b); p != "" {
+			label += " · " + p
+		}
+		options[i] = huh.NewOption(label, b.ID)
+	}
+
+	var selectedID uint32
+	err = forms.Run(huh.NewSelect[uint32]().
+		Title("Which book?").
+		Options(options...).
+		Value(&selectedID))
+	if err != nil {
+		return fmt.Errorf("book selection cancelled or failed: %w", err)
+	}
+
+	var book *models.Book
+	for i := range books {
+		if books[i].ID == selectedID {
+			book = &books[i]
+			break
+		}
+	}
+	if book == nil {
+		return fmt.Errorf("internal error: selected book %d not found", selectedID)
+	}
+
+	fields := []huh.Field{}
+	if book.Length == 0 {
+		fields = append(fields, forms.NumberInput(
+			fmt.Sprintf("Length (%s):", utils.BookUnit(book.Format)), &book.Length, nil))
+	}
+	title := "Current page:"
+	if book.Format == models.FormatAudiobook {
+		title = "Minutes listened:"
+	}
+	fields = append(fields, forms.NumberInput(title, &book.Position, func() uint32 { return book.Length }))
+
+	if err := forms.NewForm(huh.NewGroup(fields...)).Run(); err != nil {
+		return fmt.Errorf("form input error for book progress: %w", err)
+	}
+
+	if err := offerCompletion(statuses, book); err != nil {
+		return err
+	}
+
+	if err := utils.SaveBooks(books); err != nil {
+		return fmt.Errorf("failed to save books: %w", err)
+	}
+
+	if p := utils.FormatBookProgress(*book); p != "" {
+		fmt.Printf("✅ %s: %s\n", book.Title, p)
+	} else {
+		fmt.Printf("✅ %s: at %d\n", book.Title, book.Position)
+	}
+	return nil
+}
+
+// offerCompletion asks whether a book that has reached its end should move
+// to a completing status the workflow allows.
+func offerCompletion(statuses []models.StatusConfig, book *models.Book) error {
+	if book.Length == 0 || book.Position < book.Length {
+		return nil
+	}
+
+	var done models.Status
+	for _, s := range utils.NextStatuses(statuses, book.Status) {
+		if def, _ := utils.FindStatus(statuses, s); def.Complete && s != book.Status {
+			done = s
+			break
+		}
+	}
+	if done == "" {
+		return nil
+	}
+
+	var confirm bool
+	err := forms.Run(huh.NewConfirm().
+		Title(fmt.Sprintf("You've reached the end. Mark as %s?", done)).
+		Value(&confirm))
+	if err != nil || !confirm {
+		return err
+	}
+	return utils.ChangeBookStatus(statuses, book, done, "")
+}
+
+// inProgressFirst orders the books being read first: those not finished,
+// not dropped (statuses asking for a reason) and either started or past the
+// first, planning status of the workflow.
+func inProgressFirst(books []models.Book, statuses []models.StatusConfig) []models.Book {
+	var started, rest []models.Book
+	for _, b := range books {
+		def, _ := utils.FindStatus(statuses, b.Status)
+		if !def.Complete && !def.AskReason && (b.Position > 0 || b.Status != statuses[0].Name) {
+			started = append(started, b)
+		} else {
+			rest = append(rest, b)
+		}
+	}
+	return append(started, rest...)
+}
@@ -0,0 +1,355 @@
+package series
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"utilodactyl/models"
+	"utilodactyl/ui/forms"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// Special choices in the series list, kept negative so they never collide
+// with a series ID.
+const (
+	choiceNew        = -1
+	choiceNextUnread = -2
+	choiceDone       = -3
+)
+
+type seriesAction int
+
+const (
+	showOrder seriesAction = iota
+	addBooks
+	editPositions
+	removeBooks
+	renameSeries
+	deleteSeries
+	back
+)
+
+// ManageSeries lists the book series and lets the user create, rename and
+// delete them, and choose which books belong to each and in what order.
+func ManageSeries() error {
+	cursor := choiceNew
+	for {
+		books, err := utils.LoadBooks()
+		if err != nil {
+			return fmt.Errorf("failed to load books: %w", err)
+		}
+		series, err := utils.LoadSeries()
+		if err != nil {
+			return fmt.Errorf("failed to load series: %w", err)
+		}
+
+		options := make([]huh.Option[int], 0, len(series)+3)
+		for _, s := range series {
+			n := len(utils.SeriesEntries(books, s.ID))
+			options = append(options, huh.NewOption(fmt.Sprintf("%s · %d book(s)", s.Name, n), int(s.ID)))
+		}
+		options = append(options,
+			huh.NewOption("➕ New series", choiceNew),
+			huh.NewOption("📖 Next unread in each series", choiceNextUnread),
+			huh.NewOption("✅ Done", choiceDone),
+		)
+
+		err = forms.Run(huh.NewSelect[int]().
+			Title("Series:").
+			Description(fmt.Sprintf("%d series. Pick one to manage its books.", len(series))).
+			Options(options...).
+			Value(&cursor))
+		if err != nil {
+			return fmt.Errorf("series selection cancelled or failed: %w", err)
+		}
+
+		switch cursor {
+		case choiceDone:
+			return nil
+		case choiceNew:
+			var id uint32
+			if id, err = newSeries(series); err == nil {
+				cursor = int(id)
+			}
+		case choiceNextUnread:
+			err = printNextUnread(books, series)
+		default:
+			err = manageOne(books, series, uint32(cursor))
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// newSeries asks for a name and creates the series, returning its ID.
+func newSeries(series []models.Series) (uint32, error) {
+	var name string
+	if err := forms.Run(nameInput(series, 0, &name)); err != nil {
+		return 0, fmt.Errorf("form input error for series name: %w", err)
+	}
+
+	id, err := utils.GenerateSeriesID()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate unique series ID: %w", err)
+	}
+	series = append(series, models.Series{ID: id, Name: strings.TrimSpace(name)})
+	if err := utils.SaveSeries(series); err != nil {
+		return 0, fmt.Errorf("failed to save series: %w", err)
+	}
+	fmt.Printf("✅ Series %q created. Pick it to add books.\n", strings.TrimSpace(name))
+	return id, nil
+}
+
+// nameInput asks for a series name that no other series uses. self is the ID
+// of the series being renamed, or 0 for a new one.
+func nameInput(series []models.Series, self uint32, name *string) *huh.Input {
+	return huh.NewInput().
+		Title("Series name:").
+		Value(name).
+		Validate(func(s string) error {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				return fmt.Errorf("series name cannot be empty")
+			}
+			for _, other := range series {
+				if other.ID != self && strings.EqualFold(other.Name, s) {
+					return fmt.Errorf("a series named %q already exists", other.Name)
+				}
+			}
+			return nil
+		})
+}
+
+func printNextUnread(books []models.Book, series []models.Series) error {
+	statuses, err := utils.BookStatuses()
+	if err != nil {
+		return fmt.Errorf("failed to load book statuses: %w", err)
+	}
+
+	next := utils.NextUnread(books, series, statuses)
+	if len(next) == 0 {
+		fmt.Printf("No series has a book left in %q.\n", statuses[0].Name)
+		return nil
+	}
+	fmt.Println("📖 Next unread:")
+	for _, b := range next {
+		fmt.Printf("  • %s — %s\n", utils.SeriesLabel(series, b), b.Title)
+	}
+	return nil
+}
+
+func manageOne(books []models.Book, series []models.Series, id uint32) error {
+	current, ok := utils.FindSeries(series, id)
+	if !ok {
+		return fmt.Errorf("internal error: selected series %d not found", id)
+	}
+	entries := utils.SeriesEntries(books, id)
+
+	options := []huh.Option[seriesAction]{
+		huh.NewOption("Show reading order", showOrder),
+		huh.NewOption("Add books", addBooks),
+	}
+	if len(entries) > 0 {
+		options = append(options,
+			huh.NewOption("Edit positions", editPositions),
+			huh.NewOption("Remove books", removeBooks),
+		)
+	}
+	options = append(options,
+		huh.NewOption("Rename", renameSeries),
+		huh.NewOption("Delete", deleteSeries),
+		huh.NewOption("Back", back),
+	)
+
+	action := showOrder
+	err := forms.Run(huh.NewSelect[seriesAction]().
+		Title(current.Name).
+		Options(options...).
+		Value(&action))
+	if err != nil {
+		return fmt.Errorf("series action cancelled or failed: %w", err)
+	}
+
+	switch action {
+	case showOrder:
+		printOrder(current, entries)
+		return nil
+	case addBooks:
+		return addToSeries(books, series, id)
+	case editPositions:
+		return positionBooks(books, entriesOf(books, id))
+	case removeBooks:
+		return removeFromSeries(books, entries)
+	case renameSeries:
+		return rename(series, id)
+	case deleteSeries:
+		return deleteOne(books, series, current)
+	}
+	return nil
+}
+
+func printOrder(s models.Series, entries []models.Book) {
+	if len(entries) == 0 {
+		fmt.Printf("%s has no books yet.\n", s.Name)
+		return
+	}
+	fmt.Printf("📚 %s:\n", s.Name)
+	for _, b := range entries {
+		fmt.Printf("  #%s %s · %s\n", utils.FormatSeriesPosition(b.SeriesPosition), b.Title, b.Status)
+	}
+}
+
+// entriesOf returns pointers to the books of a series, in reading order, so
+// they can be edited in place.
+func entriesOf(books []models.Book, id uint32) []*models.Book {
+	indices := utils.SeriesIndices(books, id)
+	entries := make([]*models.Book, len(indices))
+	for i, j := range indices {
+		entries[i] = &books[j]
+	}
+	return entries
+}
+
+func addToSeries(books []models.Book, series []models.Series, id uint32) error {
+	var options []huh.Option[uint32]
+	for _, b := range books {
+		if b.SeriesID == id {
+			continue
+		}
+		label := utils.BookLabel(b)
+		if in := utils.SeriesLabel(series, b); in != "" {
+			label += " · in " + in
+		}
+		options = append(options, huh.NewOption(label, b.ID))
+	}
+	if len(options) == 0 {
+		fmt.Println("Every book is already in this series.")
+		return nil
+	}
+
+	var picked []uint32
+	err := forms.Run(huh.NewMultiSelect[uint32]().
+		Title("Books to add:").
+		Description("Books already in another series move to this one.").
+		Options(options...).
+		Value(&picked).
+		Height(min(len(options)+2, 12)))
+	if err != nil {
+		return fmt.Errorf("book selection cancelled or failed: %w", err)
+	}
+	if len(picked) == 0 {
+		return nil
+	}
+
+	var added []*models.Book
+	next := utils.NextPosition(books, id)
+	for i := range books {
+		if slices.Contains(picked, books[i].ID) {
+			books[i].SeriesID, books[i].SeriesPosition = id, next
+			next++
+			added = append(added, &books[i])
+		}
+	}
+	return positionBooks(books, added)
+}
+
+// positionBooks asks for the position of each of the given books, then saves
+// the collection.
+func positionBooks(books []models.Book, entries []*models.Book) error {
+	fields := make([]huh.Field, len(entries))
+	for i, b := range entries {
+		fields[i] = forms.SeriesPositionInput(b.Title+":", b)
+	}
+	err := forms.NewForm(huh.NewGroup(fields...).
+		Title("Positions").
+		Description("Decimals such as 2.5 fit a novella between two books.")).Run()
+	if err != nil {
+		return fmt.Errorf("form input error for series positions: %w", err)
+	}
+
+	if err := utils.SaveBooks(books); err != nil {
+		return fmt.Errorf("failed to save books: %w", err)
+	}
+	fmt.Println("✅ Series order saved.")
+	return nil
+}
+
+func removeFromSeries(books []models.Book, entries []models.Book) error {
+	options := make([]huh.Option[uint32], len(entries))
+	for i, b := range entries {
+		options[i] = huh.NewOption(fmt.Sprintf("#%s %s", utils.FormatSeriesPosition(b.SeriesPosition), b.Title), b.ID)
+	}
+
+	var picked []uint32
+	err := forms.Run(huh.NewMultiSelect[uint32]().
+		Title("Books to remove from the series:").
+		Options(options...).
+		Value(&picked))
+	if err != nil {
+		return fmt.Errorf("book selection cancelled or failed: %w", err)
+	}
+	if len(picked) == 0 {
+		return nil
+	}
+
+	for i := range books {
+		if slices.Contains(picked, books[i].ID) {
+			books[i].SeriesID, books[i].SeriesPosition = 0, 0
+		}
+	}
+	if err := utils.SaveBooks(books); err != nil {
+		return fmt.Errorf("failed to save books: %w", err)
+	}
+	fmt.Printf("✅ Removed %d book(s) from the series.\n", len(picked))
+	return nil
+}
+
+func rename(series []models.Series, id uint32) error {
+	i := slices.IndexFunc(series, func(s models.Series) bool { return s.ID == id })
+	name := series[i].Name
+	if err := forms.Run(nameInput(series, id, &name)); err != nil {
+		return fmt.Errorf("form input error for series name: %w", err)
+	}
+	series[i].Name = strings.TrimSpace(name)
+	if err := utils.SaveSeries(series); err != nil {
+		return fmt.Errorf("failed to save series: %w", err)
+	}
+	fmt.Println("✅ Series renamed.")
+	return nil
+}
+
+// deleteOne deletes a series after confirmation. Its books stay in the
+// collection, outside any series.
+func deleteOne(books []models.Book, series []models.Series, s models.Series) error {
+	entries := len(utils.SeriesEntries(books, s.ID))
+
+	var confirm bool
+	err := forms.Run(huh.NewConfirm().
+		Title(fmt.Sprintf("Delete %q?", s.Name)).
+		Description(fmt.Sprintf("Its %d book(s) stay in the collection, outside any series.", entries)).
+		Value(&confirm))
+	if err != nil || !confirm {
+		return err
+	}
+
+	if entries > 0 {
+		for i := range books {
+			if books[i].SeriesID == s.ID {
+				books[i].SeriesID, books[i].SeriesPosition = 0, 0
+			}
+		}
+		if err := utils.SaveBooks(books); err != nil {
+			return fmt.Errorf("failed to save books: %w", err)
+		}
+	}
+
+	series = slices.DeleteFunc(series, func(other models.Series) bool { return other.ID == s.ID })
+	if err := utils.SaveSeries(series); err != nil {
+		return fmt.Errorf("failed to save series: %w", err)
+	}
+	fmt.Printf("✅ Deleted %q.\n", s.Name)
+	return nil
+}
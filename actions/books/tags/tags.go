@@ -0,0 +1,30 @@
+package tags
+
+import (
+	"utilodactyl/actions/tags"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+)
+
+// ManageBookTags cleans up the genres and tags used across all books.
+func ManageBookTags() error {
+	return tags.Manage(tags.Collection[models.Book]{
+		Noun:  "book",
+		Load:  utils.LoadBooks,
+		Save:  utils.SaveBooks,
+		Label: func(b models.Book) string { return b.Title },
+		Fields: []tags.Field[models.Book]{
+			{
+				Name:  "genres",
+				Get:   func(b *models.Book) *[]string { return &b.Genres },
+				Terms: func(v *models.Vocabulary) *[]models.VocabTerm { return &v.Genres },
+			},
+			{
+				Name:  "tags",
+				Get:   func(b *models.Book) *[]string { return &b.Tags },
+				Terms: func(v *models.Vocabulary) *[]models.VocabTerm { return &v.Tags },
+			},
+		},
+		Vocabulary: utils.BookVocabularyFile,
+	})
+}
@@ -2,43 +2,190 @@ package view
 
 import (
 	"fmt"
-	"utilodactyl/utils"
 	"strings"
+	"time"
+	"utilodactyl/actions/books/edit"
+	"utilodactyl/models"
+	"utilodactyl/query"
+	"utilodactyl/ui/browser"
+	"utilodactyl/utils"
 )
 
+// ViewBooks opens the collection browser. Picking a book opens its edit form
+// and returns to the browser afterwards.
 func ViewBooks() error {
-	books, err := utils.LoadBooks()
-	if err != nil {
-		// Propagate the error from loading books.
-		return fmt.Errorf("failed to load books for viewing: %w", err)
+	for {
+		books, err := utils.LoadBooks()
+		if err != nil {
+			// Propagate the error from loading books.
+			return fmt.Errorf("failed to load books for viewing: %w", err)
+		}
+
+		if len(books) == 0 {
+			fmt.Println("No books to show.")
+			return nil
+		}
+
+		series, err := utils.LoadSeries()
+		if err != nil {
+			return fmt.Errorf("failed to load series for viewing: %w", err)
+		}
+		books = utils.GroupBySeries(books, series)
+
+		people, err := utils.LoadPeople()
+		if err != nil {
+			return fmt.Errorf("failed to load people for viewing: %w", err)
+		}
+
+		scale, err := utils.BookScale()
+		if err != nil {
+			return fmt.Errorf("failed to load book rating scale: %w", err)
+		}
+
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config for viewing: %w", err)
+		}
+
+		picked, err := browser.Run(browser.Config{
+			Title: "📚 Books",
+			Len:   len(books),
+			Columns: []browser.Column{
+				{
+					Title: "ID", Width: 4,
+					Value: func(i int) string { return fmt.Sprint(books[i].ID) },
+					Less:  func(a, b int) bool { return books[a].ID < books[b].ID },
+				},
+				{Title: "Title", Width: 30, Value: func(i int) string { return books[i].Title }},
+				{Title: "Author", Width: 20, Value: func(i int) string { return books[i].Author }},
+				{
+					Title: "Series", Width: 16,
+					Value: func(i int) string { return utils.SeriesLabel(series, books[i]) },
+					// The books are already grouped by series, in reading order.
+					Less: func(a, b int) bool { return a < b },
+				},
+				{
+					Title: "Rating", Width: 7,
+					Value: func(i int) string { return scale.Format(books[i].Rating) },
+					Less:  func(a, b int) bool { return books[a].Rating < books[b].Rating },
+				},
+				{Title: "Status", Width: 12, Value: func(i int) string { return string(books[i].Status) }},
+				{
+					Title: "Progress", Width: 8,
+					Value: func(i int) string {
+						if p, ok := utils.BookPercent(books[i]); ok {
+							return fmt.Sprintf("%d%%", p)
+						}
+						return ""
+					},
+					Less: func(a, b int) bool {
+						pa, _ := utils.BookPercent(books[a])
+						pb, _ := utils.BookPercent(books[b])
+						return pa < pb
+					},
+				},
+				{
+					Title: "Added", Width: 10,
+					Value: func(i int) string { return utils.FormatDate(books[i].AddedAt) },
+				},
+			},
+			Detail: func(i int) string { return bookDetail(books[i], scale, cfg.Books.PrivateFields, series, books, people) },
+			Filter: func(expr string) (func(i int) bool, error) {
+				match, err := query.Compile[models.Book](expr)
+				if err != nil {
+					return nil, err
+				}
+				return func(i int) bool { return match(&books[i]) }, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if picked < 0 {
+			return nil
+		}
+
+		if err := edit.EditBookByID(books[picked].ID); err != nil {
+			fmt.Printf("Error editing book: %v\n", err)
+		}
 	}
+}
 
-	if len(books) == 0 {
-		fmt.Println("No books to show.")
+// bookDetail formats every field of a book for the detail pane, along with
+// the reading order of its series. Fields left out of the public release are
+// marked with a lock; private is the list the collection keeps private.
+func bookDetail(book models.Book, scale utils.Scale, private []string, series []models.Series, books []models.Book, people []models.Person) string {
+	hidden := utils.Unpublished(private, book.PrivateFields)
+	lock := func(field string) string {
+		if hidden[field] && !book.Private {
+			return " 🔒"
+		}
+		return ""
+	}
+
+	var b strings.Builder
+	if book.Private {
+		b.WriteString("🔒 Private: this book is not published\n")
+	} else if len(hidden) > 0 {
+		b.WriteString("🔒 marks fields that are not published\n")
+	}
+	if book.Draft {
+		b.WriteString("📝 Draft: not published until the draft flag is cleared\n")
+	}
+	if book.PublishAt != nil && book.PublishAt.After(time.Now()) {
+		fmt.Fprintf(&b, "⏰ Scheduled: published from %s\n", utils.FormatPublishAt(book.PublishAt))
+	}
+	fmt.Fprintf(&b, "📖 %s by %s\n", book.Title, book.Author)
+	if len(book.Contributors) > 0 {
+		note := lock("contributors")
+		if note != "" {
+			note += " (the author line above stays public)"
+		}
+		fmt.Fprintf(&b, "✍️ Contributors: %s%s\n", utils.FormatContributors(people, book.Contributors), note)
+	}
+	fmt.Fprintf(&b, "⭐ Rating: %s%s\n", scale.Format(book.Rating), lock("rating"))
+	fmt.Fprintf(&b, "📚 Genres: %s%s\n", joinStringSlice(book.Genres, ", "), lock("genres"))
+	fmt.Fprintf(&b, "🏷️ Tags: %s%s\n", joinStringSlice(book.Tags, ", "), lock("tags"))
+	fmt.Fprintf(&b, "📈 Status: %s\n", book.Status)
+	if label := utils.SeriesLabel(series, book); label != "" {
+		fmt.Fprintf(&b, "📚 Series: %s%s\n", label, lock("series"))
+	}
+	if p := utils.FormatBookProgress(book); p != "" {
+		fmt.Fprintf(&b, "🔖 Progress: %s (%s)%s\n", p, book.Format, lock("progress"))
+	}
+	if len(book.StatusHistory) > 0 {
+		fmt.Fprintf(&b, "🕓 History: %s%s\n", utils.FormatStatusHistory(book.StatusHistory), lock("history"))
+	}
+	if book.AddedAt != nil || book.UpdatedAt != nil {
+		fmt.Fprintf(&b, "📅 Added: %s · Updated: %s%s\n", utils.FormatDate(book.AddedAt), utils.FormatDate(book.UpdatedAt), lock("history"))
+	}
+	if book.Explicit {
+		b.WriteString("🔞 Explicit Content: Yes\n")
 	} else {
-		// Iterate through each book and print its formatted details.
-		for _, book := range books {
-			fmt.Printf("\n📖 %s by %s\n", book.Title, book.Author)
-			fmt.Printf("⭐ Rating: %d\n", book.Rating)
-			fmt.Printf("📚 Genres: %s\n", joinStringSlice(book.Genres, ", "))
-			fmt.Printf("🏷️ Tags: %s\n", joinStringSlice(book.Tags, ", "))
-			fmt.Printf("📄 Description: %s\n", book.Description)
-			fmt.Printf("💭 Thoughts: %s\n", book.MyThoughts)
-			fmt.Printf("📈 Status: %s\n", book.Status)
-			if book.Explicit {
-				fmt.Println("🔞 Explicit Content: Yes")
-			} else {
-				fmt.Println("✅ Explicit Content: No")
-			}
-			if len(book.Links) > 0 {
-				fmt.Println("🔗 Links:")
-				for _, link := range book.Links {
-					fmt.Printf("  • %s → %s\n", link.Title, link.URL)
-				}
+		b.WriteString("✅ Explicit Content: No\n")
+	}
+	if len(book.ContentWarnings) > 0 {
+		fmt.Fprintf(&b, "⚠️ Content Warnings: %s%s\n", utils.FormatWarnings(book.ContentWarnings), lock("contentWarnings"))
+	}
+	fmt.Fprintf(&b, "\n📄 Description%s: %s\n", lock("description"), book.Description)
+	fmt.Fprintf(&b, "\n💭 Thoughts%s: %s\n", lock("myThoughts"), book.MyThoughts)
+	if entries := utils.SeriesEntries(books, book.SeriesID); book.SeriesID != 0 && len(entries) > 1 {
+		b.WriteString("\n📚 Reading order:\n")
+		for _, e := range entries {
+			marker := " "
+			if e.ID == book.ID {
+				marker = "▸"
 			}
+			fmt.Fprintf(&b, " %s #%s %s · %s\n", marker, utils.FormatSeriesPosition(e.SeriesPosition), e.Title, e.Status)
+		}
+	}
+	if len(book.Links) > 0 {
+		fmt.Fprintf(&b, "\n🔗 Links%s:\n", lock("links"))
+		for _, link := range book.Links {
+			fmt.Fprintf(&b, "  • %s → %s\n", link.Title, link.URL)
 		}
 	}
-	return nil
+	return b.String()
 }
 
 // joinStringSlice concatenates a slice of strings into a single string,
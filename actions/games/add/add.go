@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"utilodactyl/models"
+	"utilodactyl/ui/forms"
 	"utilodactyl/utils"
 
 	"github.com/charmbracelet/huh"
@@ -16,9 +17,39 @@ func AddGame() error {
 		return fmt.Errorf("failed to load books: %v", err)
 	}
 
+	statuses, err := utils.GameStatuses()
+	if err != nil {
+		return fmt.Errorf("failed to load game statuses: %w", err)
+	}
+
+	scale, err := utils.GameScale()
+	if err != nil {
+		return fmt.Errorf("failed to load game rating scale: %w", err)
+	}
+
+	cfg, err := utils.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	taxonomy, err := utils.GameWarnings()
+	if err != nil {
+		return fmt.Errorf("failed to load game content warnings: %w", err)
+	}
+
+	vocab, err := utils.LoadVocabulary(utils.GameVocabularyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load game vocabulary: %w", err)
+	}
+
+	people, err := utils.LoadPeople()
+	if err != nil {
+		return fmt.Errorf("failed to load people: %w", err)
+	}
+
 	var newGame models.Game
-	var rating int
-	var status string
+	var rating models.Rating
+	var status models.Status
 
 	basicDetailsGroup := huh.NewGroup(
 		huh.NewInput().Title("Title:").Value(&newGame.Title).Validate(func(s string) error {
@@ -28,19 +59,7 @@ func AddGame() error {
 
 			return nil
 		}),
-		huh.NewInput().
-			Title("Developer:").
-			Value(&newGame.Developer).
-			Validate(func(s string) error {
-				if strings.TrimSpace(s) == "" {
-					return fmt.Errorf("developer cannot be empty")
-				}
-				return nil
-			}),
-
-		huh.NewConfirm().
-			Title("Explicit Content:").
-			Value(&newGame.Explicit),
+		forms.PersonInput("Developer:", people, models.RoleDeveloper, &newGame.Developer),
 
 		huh.NewInput().
 			Title("Cover Image URL:").
@@ -66,46 +85,40 @@ func AddGame() error {
 				return nil
 			}),
 
-		huh.NewSelect[int]().
-			Title("Rating (1-5):").
-			Options(
-				huh.NewOption("1", 1),
-				huh.NewOption("2", 2),
-				huh.NewOption("3", 3),
-				huh.NewOption("4", 4),
-				huh.NewOption("5", 5),
-			).
-			Value(&rating),
+		forms.RatingField(scale, &rating),
 
-		huh.NewSelect[string]().
-			Title("Play Status:").
-			Options(
-				huh.NewOption("Playing", "Playing"),
-				huh.NewOption("Finished", "Finished"),
-				huh.NewOption("Plan to Play", "Plan to Play"),
-				huh.NewOption("Dropped", "Dropped"),
-			).
-			Value(&status),
-		huh.NewSelect[uint32]().
-			Title("Progression Percentage:").
-			Options(utils.GenPercentOpts()...).
-			Inline(true).
-			Value(&newGame.Percent),
+		forms.StatusSelect("Play Status:", statuses, "", &status),
+		forms.PercentInput("Progression Percentage:", &newGame.Percent),
 	)
 
-	if err = huh.NewForm(basicDetailsGroup).Run(); err != nil {
+	groups := append([]*huh.Group{basicDetailsGroup}, forms.ContentWarningGroups(taxonomy, &newGame.ContentWarnings, &newGame.Explicit)...)
+	groups = append(groups, forms.PrivacyGroups(utils.GamePrivateFields, cfg.Games.PrivateFields, &newGame.Private, &newGame.PrivateFields)...)
+	groups = append(groups, forms.ScheduleGroup(&newGame.Draft, &newGame.PublishAt))
+	if err = forms.NewForm(groups...).Run(); err != nil {
 		return fmt.Errorf("form input error for basic game details: %w", err)
 	}
 
-	newGame.Rating = uint32(rating)
-	newGame.Status = status
+	newGame.Rating = rating
+	newGame.Explicit = utils.DeriveExplicit(taxonomy, newGame.ContentWarnings, newGame.Explicit)
+	utils.LinkGamePeople(&people, &newGame)
+	reason, err := forms.AskStatusReason(statuses, status)
+	if err != nil {
+		return fmt.Errorf("form input error for game status: %w", err)
+	}
+	if err = utils.ChangeGameStatus(statuses, &newGame, status, reason); err != nil {
+		return err
+	}
 
-	if err = handleGenresAndTagsForm(games, &newGame); err != nil {
-		return fmt.Errorf("error handling book genres and tags: %w", err)
+	err = forms.EditStringSets(
+		forms.NewStringSet("genres", utils.CollectUniqueGameGenres(games), &newGame.Genres).WithVocabulary(vocab.Genres),
+		forms.NewStringSet("tags", utils.CollectUniqueGameTags(games), &newGame.Tags).WithVocabulary(vocab.Tags),
+	)
+	if err != nil {
+		return fmt.Errorf("error handling game genres and tags: %w", err)
 	}
 
-	if err = handleLinks(&newGame); err != nil {
-		return fmt.Errorf("error handling book links: %w", err)
+	if newGame.Links, err = forms.EditLinks(nil); err != nil {
+		return fmt.Errorf("error handling game links: %w", err)
 	}
 
 	newGame.ID, err = utils.GenerateGameID()
@@ -115,6 +128,10 @@ func AddGame() error {
 
 	games = append(games, newGame)
 
+	if err = utils.SavePeople(people); err != nil {
+		return fmt.Errorf("failed to save people: %w", err)
+	}
+
 	if err = utils.SaveGames(games); err != nil {
 		return fmt.Errorf("failed to save games after adding new entry: %w", err)
 	}
@@ -122,167 +139,3 @@ func AddGame() error {
 	fmt.Println("✅ Game added successfully!")
 	return nil
 }
-
-func handleGenresAndTagsForm(existingGames []models.Game, game *models.Game) error {
-	existingGenres := utils.CollectUniqueGameGenres(existingGames)
-	existingTags := utils.CollectUniqueGameTags(existingGames)
-
-	var selectedGenres []string
-	var selectedTags []string
-	var addCustomGenres bool
-	var addCustomTags bool
-	var customGenres []string
-	var customTags []string
-
-	form := huh.NewForm(
-		huh.NewGroup(
-			huh.NewMultiSelect[string]().
-				Title("Select existing genres:").
-				Options(huh.NewOptions(existingGenres...)...).
-				Value(&selectedGenres).
-				WithHeight(10),
-
-			huh.NewConfirm().
-				Title("Add custom genres?").
-				Value(&addCustomGenres),
-
-			huh.NewMultiSelect[string]().
-				Title("Select existing tags:").
-				Options(huh.NewOptions(existingTags...)...).
-				Value(&selectedTags).
-				WithHeight(10),
-
-			huh.NewConfirm().
-				Title("Add custom tags?").
-				Value(&addCustomTags),
-		),
-	)
-
-	if err := form.Run(); err != nil {
-		return err
-	}
-
-	game.Genres = append(game.Genres, selectedGenres...)
-	game.Tags = append(game.Tags, selectedTags...)
-
-	if addCustomGenres {
-		for {
-			var genre string
-			if err := huh.NewInput().
-				Title("New genre:").
-				Value(&genre).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("genre cannot be empty")
-					}
-					return nil
-				}).
-				Run(); err != nil {
-				return err
-			}
-			customGenres = append(customGenres, genre)
-
-			var more bool
-			if err := huh.NewConfirm().
-				Title("Add another genre?").
-				Value(&more).
-				Run(); err != nil {
-				return err
-			}
-			if !more {
-				break
-			}
-		}
-		game.Genres = append(game.Genres, customGenres...)
-	}
-
-	if addCustomTags {
-		for {
-			var tag string
-			if err := huh.NewInput().
-				Title("New tag:").
-				Value(&tag).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("tag cannot be empty")
-					}
-					return nil
-				}).
-				Run(); err != nil {
-				return err
-			}
-			customTags = append(customTags, tag)
-
-			var more bool
-			if err := huh.NewConfirm().
-				Title("Add another tag?").
-				Value(&more).
-				Run(); err != nil {
-				return err
-			}
-			if !more {
-				break
-			}
-		}
-		game.Tags = append(game.Tags, customTags...)
-	}
-
-	return nil
-}
-
-func handleLinks(book *models.Game) error {
-	var confirmAddLink bool
-	err := huh.NewConfirm().
-		Title("Add links?").
-		Value(&confirmAddLink).
-		Run()
-	if err != nil {
-		return err
-	}
-
-	if confirmAddLink {
-		for {
-			var linkTitle, linkURL string
-			err = huh.NewInput().
-				Title("Link title:").
-				Value(&linkTitle).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("link title cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			err = huh.NewInput().
-				Title("Link URL:").
-				Value(&linkURL).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("link URL cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			book.Links = append(book.Links, models.ItemLink{Title: linkTitle, URL: linkURL})
-
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another link?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
-	}
-	return nil
-}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"utilodactyl/models"
+	"utilodactyl/ui/forms"
 	"utilodactyl/utils"
 
 	"github.com/charmbracelet/huh"
@@ -20,37 +21,90 @@ func EditGame() error {
 		return nil
 	}
 
-	gameTitles := make([]string, len(games))
-	for i, b := range games {
-		gameTitles[i] = b.Title
+	candidates, err := forms.FilterPrompt(games, "games")
+	if err != nil {
+		return fmt.Errorf("game filter cancelled or failed: %w", err)
+	}
+
+	gameOptions := make([]huh.Option[uint32], len(candidates))
+	for i, g := range candidates {
+		gameOptions[i] = huh.NewOption(utils.GameLabel(g), g.ID)
 	}
 
-	var selectedTitle string
-	err = huh.NewSelect[string]().
+	var selectedID uint32
+	err = forms.Run(huh.NewSelect[uint32]().
 		Title("Choose a game to edit:").
-		Options(huh.NewOptions(gameTitles...)...).
-		Value(&selectedTitle).
-		Run()
+		Options(gameOptions...).
+		Value(&selectedID))
 	if err != nil {
 		return fmt.Errorf("game selection cancelled or failed: %w", err)
 	}
 
 	var gameToEdit *models.Game
 	for i := range games {
-		if games[i].Title == selectedTitle {
+		if games[i].ID == selectedID {
 			gameToEdit = &games[i]
 			break
 		}
 	}
 
 	if gameToEdit == nil {
-		return fmt.Errorf("internal error: selected game '%s' not found", selectedTitle)
+		return fmt.Errorf("internal error: selected game %d not found", selectedID)
+	}
+
+	return editGame(games, gameToEdit)
+}
+
+// EditGameByID opens the edit form for the game with the given ID.
+func EditGameByID(id uint32) error {
+	games, err := utils.LoadGames()
+	if err != nil {
+		return fmt.Errorf("failed to load games for editing: %w", err)
+	}
+
+	for i := range games {
+		if games[i].ID == id {
+			return editGame(games, &games[i])
+		}
 	}
+	return fmt.Errorf("game with id %d not found", id)
+}
 
-	rating := int(gameToEdit.Rating)
+func editGame(games []models.Game, gameToEdit *models.Game) error {
+	statuses, err := utils.GameStatuses()
+	if err != nil {
+		return fmt.Errorf("failed to load game statuses: %w", err)
+	}
+
+	scale, err := utils.GameScale()
+	if err != nil {
+		return fmt.Errorf("failed to load game rating scale: %w", err)
+	}
+
+	cfg, err := utils.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	taxonomy, err := utils.GameWarnings()
+	if err != nil {
+		return fmt.Errorf("failed to load game content warnings: %w", err)
+	}
+
+	vocab, err := utils.LoadVocabulary(utils.GameVocabularyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load game vocabulary: %w", err)
+	}
+
+	people, err := utils.LoadPeople()
+	if err != nil {
+		return fmt.Errorf("failed to load people: %w", err)
+	}
+
+	rating := gameToEdit.Rating
 	status := gameToEdit.Status
 
-	basicDetailsForm := huh.NewForm(
+	groups := []*huh.Group{
 		huh.NewGroup(
 			huh.NewInput().
 				Title("Title:").
@@ -61,27 +115,11 @@ func EditGame() error {
 					}
 					return nil
 				}),
-			huh.NewInput().
-				Title("Developer:").
-				Value(&gameToEdit.Developer).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("author cannot be empty")
-					}
-					return nil
-				}),
-			huh.NewConfirm().
-				Title("Explicit Content:").
-				Value(&gameToEdit.Explicit),
+			forms.PersonInput("Developer:", people, models.RoleDeveloper, &gameToEdit.Developer),
 			huh.NewInput().
 				Title("Cover Image URL:").
 				Value(&gameToEdit.CoverImage).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("cover image URL cannot be empty")
-					}
-					return nil
-				}),
+				Validate(utils.ValidateURL),
 			huh.NewText().
 				Title("Description:").
 				Value(&gameToEdit.Description).
@@ -100,234 +138,60 @@ func EditGame() error {
 					}
 					return nil
 				}),
-			huh.NewSelect[int]().
-				Title("Rating (1–5):").
-				Options(
-					huh.NewOption("1", 1),
-					huh.NewOption("2", 2),
-					huh.NewOption("3", 3),
-					huh.NewOption("4", 4),
-					huh.NewOption("5", 5),
-				).
-				Value(&rating),
-			huh.NewSelect[string]().
-				Title("Play Status:").
-				Options(
-					huh.NewOption("Playing", "Playing"),
-					huh.NewOption("Finished", "Finished"),
-					huh.NewOption("Plan to Play", "Plan to Play"),
-					huh.NewOption("Dropped", "Dropped"),
-				).
-				Value(&gameToEdit.Status),
-			huh.NewSelect[uint32]().
-				Title("Progression Percentage:").
-				Options(utils.GenPercentOpts()...).
-				Inline(true).
-				Value(&gameToEdit.Percent),
+			forms.RatingField(scale, &rating),
+			forms.StatusSelect("Play Status:", statuses, gameToEdit.Status, &status),
+			forms.PercentInput("Progression Percentage:", &gameToEdit.Percent),
 		),
-	)
+	}
+	groups = append(groups, forms.ContentWarningGroups(taxonomy, &gameToEdit.ContentWarnings, &gameToEdit.Explicit)...)
+	groups = append(groups, forms.PrivacyGroups(utils.GamePrivateFields, cfg.Games.PrivateFields, &gameToEdit.Private, &gameToEdit.PrivateFields)...)
+	groups = append(groups, forms.ScheduleGroup(&gameToEdit.Draft, &gameToEdit.PublishAt))
+	basicDetailsForm := forms.NewForm(groups...)
 
-	if err = basicDetailsForm.Run(); err != nil {
+	if err := basicDetailsForm.Run(); err != nil {
 		return fmt.Errorf("form input error for game details: %w", err)
 	}
 
 	if (gameToEdit.Title == "P5R") {
 		gameToEdit.Rating = 500
 	} else {
-		gameToEdit.Rating = uint32(rating)
-	}
-	gameToEdit.Rating = uint32(rating)
-	gameToEdit.Status = status
-
-	if err = editGenres(games, gameToEdit); err != nil {
-		return fmt.Errorf("error editing game genres: %w", err)
+		gameToEdit.Rating = rating
 	}
-
-	if err = editTags(games, gameToEdit); err != nil {
-		return fmt.Errorf("error editing game tags: %w", err)
-	}
-
-	if err = editLinks(gameToEdit); err != nil {
-		return fmt.Errorf("error editing game links: %w", err)
-	}
-
-	if err = utils.SaveGames(games); err != nil {
-		return fmt.Errorf("failed to save games after editing: %w", err)
-	}
-
-	fmt.Println("✅ Game updated successfully!")
-	return nil
-}
-
-func editGenres(allGames []models.Game, game *models.Game) error {
-	existingGenres := utils.CollectUniqueGameGenres(allGames)
-	if len(existingGenres) > 0 {
-		selectedGenres := game.Genres
-		err := huh.NewMultiSelect[string]().
-			Title("Select/Deselect existing genres:").
-			Options(huh.NewOptions(existingGenres...)...).
-			Value(&selectedGenres).
-			Run()
+	gameToEdit.Rating = rating
+	gameToEdit.Explicit = utils.DeriveExplicit(taxonomy, gameToEdit.ContentWarnings, gameToEdit.Explicit)
+	utils.LinkGamePeople(&people, gameToEdit)
+	if status != gameToEdit.Status {
+		reason, err := forms.AskStatusReason(statuses, status)
 		if err != nil {
+			return fmt.Errorf("form input error for game status: %w", err)
+		}
+		if err := utils.ChangeGameStatus(statuses, gameToEdit, status, reason); err != nil {
 			return err
 		}
-		game.Genres = selectedGenres
 	}
 
-	var confirmAddGenre bool
-	err := huh.NewConfirm().
-		Title("Add custom genres?").
-		Value(&confirmAddGenre).
-		Run()
+	err = forms.EditStringSets(
+		forms.NewStringSet("genres", utils.CollectUniqueGameGenres(games), &gameToEdit.Genres).WithVocabulary(vocab.Genres),
+		forms.NewStringSet("tags", utils.CollectUniqueGameTags(games), &gameToEdit.Tags).WithVocabulary(vocab.Tags),
+	)
 	if err != nil {
-		return err
-	}
-
-	if confirmAddGenre {
-		for {
-			var customGenre string
-			err = huh.NewInput().
-				Title("New genre:").
-				Value(&customGenre).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("genre cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			game.Genres = append(game.Genres, customGenre)
-
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another genre?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
-	}
-	return nil
-}
-
-func editTags(allGames []models.Game, game *models.Game) error {
-	existingTags := utils.CollectUniqueGameTags(allGames)
-	if len(existingTags) > 0 {
-		selectedTags := game.Tags
-		err := huh.NewMultiSelect[string]().
-			Title("Select/Deselect existing tags:").
-			Options(huh.NewOptions(existingTags...)...).
-			Value(&selectedTags).
-			Run()
-		if err != nil {
-			return err
-		}
-		game.Tags = selectedTags
+		return fmt.Errorf("error editing game genres and tags: %w", err)
 	}
 
-	var confirmAddTag bool
-	err := huh.NewConfirm().
-		Title("Add custom tags?").
-		Value(&confirmAddTag).
-		Run()
+	links, err := forms.EditLinks(gameToEdit.Links)
 	if err != nil {
-		return err
+		return fmt.Errorf("error editing game links: %w", err)
 	}
+	gameToEdit.Links = links
 
-	if confirmAddTag {
-		for {
-			var customTag string
-			err = huh.NewInput().
-				Title("New tag:").
-				Value(&customTag).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("tag cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			game.Tags = append(game.Tags, customTag)
-
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another tag?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
+	if err := utils.SavePeople(people); err != nil {
+		return fmt.Errorf("failed to save people: %w", err)
 	}
-	return nil
-}
 
-func editLinks(game *models.Game) error {
-	var confirmAddLink bool
-	err := huh.NewConfirm().
-		Title("Add more links?").
-		Value(&confirmAddLink).
-		Run()
-	if err != nil {
-		return err
+	if err := utils.SaveGames(games); err != nil {
+		return fmt.Errorf("failed to save games after editing: %w", err)
 	}
 
-	if confirmAddLink {
-		for {
-			var linkTitle, linkURL string
-			err = huh.NewInput().
-				Title("Link title:").
-				Value(&linkTitle).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("link title cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			err = huh.NewInput().
-				Title("Link URL:").
-				Value(&linkURL).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("link URL cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			game.Links = append(game.Links, models.ItemLink{Title: linkTitle, URL: linkURL})
-
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another link?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
-	}
+	fmt.Println("✅ Game updated successfully!")
 	return nil
 }
@@ -0,0 +1,41 @@
+// Package list
+package list
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"utilodactyl/query"
+	"utilodactyl/utils"
+)
+
+// ListGames prints the games matching expr, one per line. An empty expr lists
+// every game.
+func ListGames(expr string) error {
+	games, err := utils.LoadGames()
+	if err != nil {
+		return fmt.Errorf("failed to load games: %w", err)
+	}
+
+	games, err = query.Filter(games, expr)
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	if len(games) == 0 {
+		fmt.Println("No matching games.")
+		return nil
+	}
+
+	scale, err := utils.GameScale()
+	if err != nil {
+		return fmt.Errorf("failed to load game rating scale: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTITLE\tDEVELOPER\tRATING\tSTATUS\tPERCENT\tPLAYTIME")
+	for _, g := range games {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d%%\t%s\n", g.ID, g.Title, g.Developer, scale.Format(g.Rating), g.Status, g.Percent, utils.FormatMinutes(utils.Playtime(g)))
+	}
+	return w.Flush()
+}
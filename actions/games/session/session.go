@@ -0,0 +1,160 @@
+package session
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"utilodactyl/models"
+	"utilodactyl/ui/forms"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// LogSession records a play session for a game without going through the
+// whole edit form. Recently played games are offered first, and reaching
+// 100% offers to move the game to a completing status.
+func LogSession() error {
+	games, err := utils.LoadGames()
+	if err != nil {
+		return fmt.Errorf("failed to load games: %w", err)
+	}
+	statuses, err := utils.GameStatuses()
+	if err != nil {
+		return fmt.Errorf("failed to load game statuses: %w", err)
+	}
+
+	if len(games) == 0 {
+		fmt.Println("No games available.")
+		return nil
+	}
+
+	candidates, err := forms.FilterPrompt(recentFirst(games), "games")
+	if err != nil {
+		return fmt.Errorf("game filter cancelled or failed: %w", err)
+	}
+
+	options := make([]huh.Option[uint32], len(candidates))
+	for i, g := range candidates {
+		label := utils.GameLabel(g)
+		if last := utils.LastPlayed(g); last != "" {
+			label += " · last played " + last
+		}
+		options[i] = huh.NewOption(label, g.ID)
+	}
+
+	var selectedID uint32
+	err = forms.Run(huh.NewSelect[uint32]().
+		Title("Which game?").
+		Options(options...).
+		Value(&selectedID))
+	if err != nil {
+		return fmt.Errorf("game selection cancelled or failed: %w", err)
+	}
+
+	var game *models.Game
+	for i := range games {
+		if games[i].ID == selectedID {
+			game = &games[i]
+			break
+		}
+	}
+	if game == nil {
+		return fmt.Errorf("internal error: selected game %d not found", selectedID)
+	}
+
+	session := models.PlaySession{Date: utils.Today()}
+	var duration string
+	percent := game.Percent
+
+	err = forms.NewForm(huh.NewGroup(
+		huh.NewInput().
+			Title("Date:").
+			Description("YYYY-MM-DD").
+			Value(&session.Date).
+			Validate(func(s string) error {
+				if _, err := time.Parse(utils.SessionDateLayout, strings.TrimSpace(s)); err != nil {
+					return fmt.Errorf("enter a date as YYYY-MM-DD")
+				}
+				return nil
+			}),
+		huh.NewInput().
+			Title("How long did you play?").
+			Description("e.g. 90, 1:30 or 1h30m").
+			Value(&duration).
+			Validate(func(s string) error {
+				_, err := utils.ParseMinutes(s)
+				return err
+			}),
+		huh.NewText().
+			Title("Note:").
+			Description("Optional.").
+			Value(&session.Note),
+		forms.PercentInput("Progression Percentage:", &percent),
+	)).Run()
+	if err != nil {
+		return fmt.Errorf("form input error for play session: %w", err)
+	}
+
+	session.Date = strings.TrimSpace(session.Date)
+	session.Note = strings.TrimSpace(session.Note)
+	if session.Minutes, err = utils.ParseMinutes(duration); err != nil {
+		return err
+	}
+	if percent != game.Percent {
+		session.Percent = &percent
+		game.Percent = percent
+	}
+	game.Sessions = append(game.Sessions, session)
+
+	if err := offerCompletion(statuses, game); err != nil {
+		return err
+	}
+
+	if err := utils.SaveGames(games); err != nil {
+		return fmt.Errorf("failed to save games: %w", err)
+	}
+
+	fmt.Printf("✅ %s: %s logged, %s in total\n", game.Title,
+		utils.FormatMinutes(session.Minutes), utils.FormatMinutes(utils.Playtime(*game)))
+	return nil
+}
+
+// offerCompletion asks whether a game that has reached 100% should move to a
+// completing status the workflow allows.
+func offerCompletion(statuses []models.StatusConfig, game *models.Game) error {
+	if game.Percent < 100 {
+		return nil
+	}
+
+	var done models.Status
+	for _, s := range utils.NextStatuses(statuses, game.Status) {
+		if def, _ := utils.FindStatus(statuses, s); def.Complete && s != game.Status {
+			done = s
+			break
+		}
+	}
+	if done == "" {
+		return nil
+	}
+
+	var confirm bool
+	err := forms.Run(huh.NewConfirm().
+		Title(fmt.Sprintf("You've reached 100%%. Mark as %s?", done)).
+		Value(&confirm))
+	if err != nil || !confirm {
+		return err
+	}
+	return utils.ChangeGameStatus(statuses, game, done, "")
+}
+
+// recentFirst orders games by when they were last played, most recent first,
+// followed by the games never played in their original order.
+func recentFirst(games []models.Game) []models.Game {
+	sorted := slices.Clone(games)
+	slices.SortStableFunc(sorted, func(a, b models.Game) int {
+		return strings.Compare(utils.LastPlayed(b), utils.LastPlayed(a))
+	})
+	return sorted
+}
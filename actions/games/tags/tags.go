@@ -0,0 +1,30 @@
+package tags
+
+import (
+	"utilodactyl/actions/tags"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+)
+
+// ManageGameTags cleans up the genres and tags used across all games.
+func ManageGameTags() error {
+	return tags.Manage(tags.Collection[models.Game]{
+		Noun:  "game",
+		Load:  utils.LoadGames,
+		Save:  utils.SaveGames,
+		Label: func(g models.Game) string { return g.Title },
+		Fields: []tags.Field[models.Game]{
+			{
+				Name:  "genres",
+				Get:   func(g *models.Game) *[]string { return &g.Genres },
+				Terms: func(v *models.Vocabulary) *[]models.VocabTerm { return &v.Genres },
+			},
+			{
+				Name:  "tags",
+				Get:   func(g *models.Game) *[]string { return &g.Tags },
+				Terms: func(v *models.Vocabulary) *[]models.VocabTerm { return &v.Tags },
+			},
+		},
+		Vocabulary: utils.GameVocabularyFile,
+	})
+}
@@ -2,42 +2,170 @@ package view
 
 import (
 	"fmt"
-	"utilodactyl/utils"
 	"strings"
+	"time"
+	"utilodactyl/actions/games/edit"
+	"utilodactyl/models"
+	"utilodactyl/query"
+	"utilodactyl/ui/browser"
+	"utilodactyl/utils"
 )
 
 func ViewGames() error {
-	games, err := utils.LoadGames()
-	if err != nil {
-		return fmt.Errorf("failed to load games for viewing: %w", err)
+	for {
+		games, err := utils.LoadGames()
+		if err != nil {
+			return fmt.Errorf("failed to load games for viewing: %w", err)
+		}
+
+		if len(games) == 0 {
+			fmt.Println("No games to show.")
+			return nil
+		}
+
+		scale, err := utils.GameScale()
+		if err != nil {
+			return fmt.Errorf("failed to load game rating scale: %w", err)
+		}
+
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config for viewing: %w", err)
+		}
+
+		picked, err := browser.Run(browser.Config{
+			Title: "🎮 Games",
+			Len:   len(games),
+			Columns: []browser.Column{
+				{
+					Title: "ID", Width: 4,
+					Value: func(i int) string { return fmt.Sprint(games[i].ID) },
+					Less:  func(a, b int) bool { return games[a].ID < games[b].ID },
+				},
+				{Title: "Title", Width: 30, Value: func(i int) string { return games[i].Title }},
+				{Title: "Developer", Width: 20, Value: func(i int) string { return games[i].Developer }},
+				{
+					Title: "Rating", Width: 7,
+					Value: func(i int) string { return scale.Format(games[i].Rating) },
+					Less:  func(a, b int) bool { return games[a].Rating < games[b].Rating },
+				},
+				{Title: "Status", Width: 12, Value: func(i int) string { return string(games[i].Status) }},
+				{
+					Title: "%", Width: 4,
+					Value: func(i int) string { return fmt.Sprint(games[i].Percent) },
+					Less:  func(a, b int) bool { return games[a].Percent < games[b].Percent },
+				},
+				{
+					Title: "Played", Width: 8,
+					Value: func(i int) string { return playtime(games[i]) },
+					Less:  func(a, b int) bool { return utils.Playtime(games[a]) < utils.Playtime(games[b]) },
+				},
+				{Title: "Last Played", Width: 11, Value: func(i int) string { return utils.LastPlayed(games[i]) }},
+				{
+					Title: "Added", Width: 10,
+					Value: func(i int) string { return utils.FormatDate(games[i].AddedAt) },
+				},
+			},
+			Detail: func(i int) string { return gameDetail(games[i], scale, cfg.Games.PrivateFields) },
+			Filter: func(expr string) (func(i int) bool, error) {
+				match, err := query.Compile[models.Game](expr)
+				if err != nil {
+					return nil, err
+				}
+				return func(i int) bool { return match(&games[i]) }, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if picked < 0 {
+			return nil
+		}
+
+		if err := edit.EditGameByID(games[picked].ID); err != nil {
+			fmt.Printf("Error editing game: %v\n", err)
+		}
 	}
+}
 
-	if len(games) == 0 {
-		fmt.Println("No games to show.")
+// gameDetail formats every field of a game for the detail pane. Fields left
+// out of the public release are marked with a lock; private is the list the
+// collection keeps private.
+func gameDetail(game models.Game, scale utils.Scale, private []string) string {
+	hidden := utils.Unpublished(private, game.PrivateFields)
+	lock := func(field string) string {
+		if hidden[field] && !game.Private {
+			return " 🔒"
+		}
+		return ""
+	}
+
+	var b strings.Builder
+	if game.Private {
+		b.WriteString("🔒 Private: this game is not published\n")
+	} else if len(hidden) > 0 {
+		b.WriteString("🔒 marks fields that are not published\n")
+	}
+	if game.Draft {
+		b.WriteString("Draft: not published until the draft flag is cleared\n")
+	}
+	if game.PublishAt != nil && game.PublishAt.After(time.Now()) {
+		fmt.Fprintf(&b, "Scheduled: published from %s\n", utils.FormatPublishAt(game.PublishAt))
+	}
+	fmt.Fprintf(&b, "%s by %s\n", game.Title, game.Developer)
+	fmt.Fprintf(&b, "Rating: %s%s\n", scale.Format(game.Rating), lock("rating"))
+	fmt.Fprintf(&b, "Genres: %s%s\n", joinStringSlice(game.Genres, ", "), lock("genres"))
+	fmt.Fprintf(&b, "Tags: %s%s\n", joinStringSlice(game.Tags, ", "), lock("tags"))
+	fmt.Fprintf(&b, "Status: %s\n", game.Status)
+	if len(game.StatusHistory) > 0 {
+		fmt.Fprintf(&b, "History: %s%s\n", utils.FormatStatusHistory(game.StatusHistory), lock("history"))
+	}
+	if game.AddedAt != nil || game.UpdatedAt != nil {
+		fmt.Fprintf(&b, "Added: %s · Updated: %s%s\n", utils.FormatDate(game.AddedAt), utils.FormatDate(game.UpdatedAt), lock("history"))
+	}
+	fmt.Fprintf(&b, "Progression: %d%s\n", game.Percent, lock("progress"))
+	if len(game.Sessions) > 0 {
+		fmt.Fprintf(&b, "Playtime: %s over %d session(s) · Last played: %s%s\n",
+			playtime(game), len(game.Sessions), utils.LastPlayed(game), lock("sessions"))
+	}
+	if game.Explicit {
+		b.WriteString("Explicit Content: Yes\n")
 	} else {
-		for _, game := range games {
-			fmt.Printf("\n%s by %s\n", game.Title, game.Developer)
-			fmt.Printf("Rating: %d\n", game.Rating)
-			fmt.Printf("Genres: %s\n", joinStringSlice(game.Genres, ", "))
-			fmt.Printf("Tags: %s\n", joinStringSlice(game.Tags, ", "))
-			fmt.Printf("Description: %s\n", game.Description)
-			fmt.Printf("Thoughts: %s\n", game.MyThoughts)
-			fmt.Printf("Status: %s\n", game.Status)
-			fmt.Printf("Progression: %d\n", game.Percent)
-			if game.Explicit {
-				fmt.Println("Explicit Content: Yes")
-			} else {
-				fmt.Println("Explicit Content: No")
+		b.WriteString("Explicit Content: No\n")
+	}
+	if len(game.ContentWarnings) > 0 {
+		fmt.Fprintf(&b, "Content Warnings: %s%s\n", utils.FormatWarnings(game.ContentWarnings), lock("contentWarnings"))
+	}
+	fmt.Fprintf(&b, "\nDescription%s: %s\n", lock("description"), game.Description)
+	fmt.Fprintf(&b, "\nThoughts%s: %s\n", lock("myThoughts"), game.MyThoughts)
+	if len(game.Sessions) > 0 {
+		fmt.Fprintf(&b, "\nSessions%s:\n", lock("sessions"))
+		for _, s := range game.Sessions {
+			fmt.Fprintf(&b, "  • %s · %s", s.Date, utils.FormatMinutes(s.Minutes))
+			if s.Percent != nil {
+				fmt.Fprintf(&b, " · %d%%", *s.Percent)
 			}
-			if len(game.Links) > 0 {
-				fmt.Println("Links:")
-				for _, link := range game.Links {
-					fmt.Printf("  • %s → %s\n", link.Title, link.URL)
-				}
+			if s.Note != "" {
+				fmt.Fprintf(&b, " · %s", s.Note)
 			}
+			b.WriteString("\n")
+		}
+	}
+	if len(game.Links) > 0 {
+		fmt.Fprintf(&b, "\nLinks%s:\n", lock("links"))
+		for _, link := range game.Links {
+			fmt.Fprintf(&b, "  • %s → %s\n", link.Title, link.URL)
 		}
 	}
-	return nil
+	return b.String()
+}
+
+// playtime renders the total time logged for a game, or "" if none was.
+func playtime(game models.Game) string {
+	if len(game.Sessions) == 0 {
+		return ""
+	}
+	return utils.FormatMinutes(utils.Playtime(game))
 }
 
 func joinStringSlice(s []string, sep string) string {
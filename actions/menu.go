@@ -0,0 +1,178 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"utilodactyl/ui/forms"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// menuItem is one option in a menu. It either runs an action or opens a
+// submenu.
+type menuItem struct {
+	action  AppAction
+	run     func() error
+	doing   string // Describes run for error messages, e.g. "adding book".
+	submenu func() *menu
+}
+
+// menu is one level of the navigation stack.
+type menu struct {
+	name  string // Shown in the breadcrumbs.
+	note  string // Optional banner shown under the breadcrumbs.
+	items []menuItem
+}
+
+// errBack is returned by runMenu when the user pressed escape.
+var errBack = errors.New("back")
+
+// navigate runs the menu loop starting at root until the user quits. Escape
+// and the Back option return to the previous menu; Exit, ctrl+c, or escape on
+// the root menu leave the app.
+func navigate(root func() *menu) error {
+	stack := []*menu{root()}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+
+		choice, err := runMenu(stack)
+		if errors.Is(err, errBack) {
+			choice = Back
+		} else if errors.Is(err, huh.ErrUserAborted) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch choice {
+		case Back:
+			stack = stack[:len(stack)-1]
+			if len(stack) == 1 {
+				// Rebuild the root menu so its banner is up to date.
+				stack[0] = root()
+			}
+			continue
+		case ExitApp:
+			return nil
+		}
+
+		for _, item := range current.items {
+			if item.action != choice {
+				continue
+			}
+			if item.submenu != nil {
+				stack = append(stack, item.submenu())
+				break
+			}
+			if err := item.run(); err != nil {
+				if errors.Is(err, huh.ErrUserAborted) {
+					fmt.Println("Cancelled.")
+				} else {
+					fmt.Printf("Error %s: %v\n", item.doing, err)
+				}
+			}
+			if len(stack) == 1 {
+				stack[0] = root()
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+// runMenu shows the menu on top of the stack with its breadcrumbs and returns
+// the chosen action.
+func runMenu(stack []*menu) (AppAction, error) {
+	current := stack[len(stack)-1]
+
+	crumbs := make([]string, len(stack))
+	for i, m := range stack {
+		crumbs[i] = m.name
+	}
+
+	options := make([]huh.Option[AppAction], 0, len(current.items)+2)
+	for _, item := range current.items {
+		options = append(options, huh.NewOption(string(item.action), item.action))
+	}
+	if len(stack) > 1 {
+		options = append(options, huh.NewOption(string(Back), Back))
+	}
+	options = append(options, huh.NewOption(string(ExitApp), ExitApp))
+
+	description := "What would you like to do?"
+	if current.note != "" {
+		description += "\n" + current.note
+	}
+
+	// Escape means "back" in menus, so the select's filter (which also uses
+	// escape) is turned off.
+	keymap := forms.KeyMap()
+	keymap.Select.Filter.SetEnabled(false)
+
+	var choice AppAction
+	form := huh.NewForm(huh.NewGroup(
+		huh.NewSelect[AppAction]().
+			Title(strings.Join(crumbs, " › ")).
+			Description(description).
+			Options(options...).
+			Value(&choice),
+	)).WithKeyMap(keymap)
+
+	if err := runEscapable(form); err != nil {
+		return "", err
+	}
+	return choice, nil
+}
+
+// escapable wraps a form so that escape leaves it instead of being ignored.
+type escapable struct {
+	form    *huh.Form
+	escaped bool
+}
+
+func (e *escapable) Init() tea.Cmd {
+	return e.form.Init()
+}
+
+func (e *escapable) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "esc" {
+		e.escaped = true
+		return e, tea.Quit
+	}
+	m, cmd := e.form.Update(msg)
+	if f, ok := m.(*huh.Form); ok {
+		e.form = f
+	}
+	return e, cmd
+}
+
+func (e *escapable) View() string {
+	if e.escaped {
+		return ""
+	}
+	return e.form.View()
+}
+
+// runEscapable runs form like huh.Form.Run, but returns errBack when the user
+// presses escape and huh.ErrUserAborted on ctrl+c.
+func runEscapable(form *huh.Form) error {
+	form.SubmitCmd = tea.Quit
+	form.CancelCmd = tea.Quit
+
+	e := &escapable{form: form}
+	if _, err := tea.NewProgram(e).Run(); err != nil {
+		return fmt.Errorf("menu failed: %w", err)
+	}
+
+	switch {
+	case e.escaped:
+		return errBack
+	case form.State == huh.StateAborted:
+		return huh.ErrUserAborted
+	}
+	return nil
+}
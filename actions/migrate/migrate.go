@@ -0,0 +1,39 @@
+// Package migrate brings collection files written by older versions up to
+// date.
+package migrate
+
+import (
+	"fmt"
+	"utilodactyl/utils"
+)
+
+// steps are the migrations, each returning how many entries it changed.
+var steps = []struct {
+	fileName string
+	done     string
+	run      func() (int, error)
+}{
+	{"projects.json", "gave %d project(s) an ID", utils.MigrateProjects},
+	{"reviews.json", "gave %d review(s) an ID", utils.MigrateReviews},
+}
+
+// Migrate runs every migration, writing only the files that change. Loading a
+// collection applies the same migrations in memory, so running this is never
+// required; it just makes them permanent without editing an entry.
+func Migrate() error {
+	changed := false
+	for _, step := range steps {
+		n, err := step.run()
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", step.fileName, err)
+		}
+		if n > 0 {
+			changed = true
+			fmt.Printf("✅ %s: "+step.done+".\n", step.fileName, n)
+		}
+	}
+	if !changed {
+		fmt.Println("Nothing to migrate.")
+	}
+	return nil
+}
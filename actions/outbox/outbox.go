@@ -0,0 +1,37 @@
+// Package outbox
+package outbox
+
+import (
+	"fmt"
+	"utilodactyl/utils"
+)
+
+// Sync uploads every collection queued in the outbox, oldest first.
+func Sync() error {
+	entries, err := utils.LoadOutbox()
+	if err != nil {
+		return fmt.Errorf("failed to load outbox: %w", err)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("Nothing to sync.")
+		return nil
+	}
+
+	flushed, dropped, err := utils.FlushOutbox(func(fileName string) error {
+		fmt.Printf("Uploading %s...\n", fileName)
+		return utils.PublishCollection(fileName)
+	})
+	for _, e := range dropped {
+		fmt.Printf("❌ %s removed from the outbox, since retrying would not help: %s\n", e.FileName, e.LastError)
+	}
+	if err != nil {
+		return fmt.Errorf("synced %d of %d collections: %w", flushed, len(entries), err)
+	}
+	if len(dropped) > 0 {
+		return fmt.Errorf("synced %d of %d collections; fix the ones removed and publish them again", flushed, len(entries))
+	}
+
+	fmt.Printf("✅ Synced %d collections.\n", flushed)
+	return nil
+}
@@ -0,0 +1,311 @@
+// Package people manages the registry of people and studios credited by the
+// book and game collections.
+package people
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"utilodactyl/models"
+	"utilodactyl/ui/forms"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// Special choices in the people list, kept negative so they never collide
+// with a person ID.
+const (
+	choiceImport = -1
+	choiceDone   = -2
+)
+
+type personAction int
+
+const (
+	editPerson personAction = iota
+	mergePerson
+	deletePerson
+	back
+)
+
+// registry is everything a change to the people registry may touch.
+type registry struct {
+	people []models.Person
+	books  []models.Book
+	games  []models.Game
+}
+
+func load() (*registry, error) {
+	people, err := utils.LoadPeople()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load people: %w", err)
+	}
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load books: %w", err)
+	}
+	games, err := utils.LoadGames()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load games: %w", err)
+	}
+	return &registry{people: people, books: books, games: games}, nil
+}
+
+// save writes the registry, then the collections referring to it.
+func (r *registry) save() error {
+	if err := utils.ValidatePeople(r.people); err != nil {
+		return fmt.Errorf("people registry would be invalid: %w", err)
+	}
+	if err := utils.SavePeople(r.people); err != nil {
+		return fmt.Errorf("failed to save people: %w", err)
+	}
+	if err := utils.SaveBooks(r.books); err != nil {
+		return fmt.Errorf("failed to save books: %w", err)
+	}
+	if err := utils.SaveGames(r.games); err != nil {
+		return fmt.Errorf("failed to save games: %w", err)
+	}
+	return nil
+}
+
+// credits counts the books and games crediting a person.
+func (r *registry) credits(p models.Person) int {
+	n := 0
+	for _, b := range r.books {
+		if slices.ContainsFunc(b.Contributors, func(c models.Contributor) bool { return c.PersonID == p.ID }) {
+			n++
+		}
+	}
+	for _, g := range r.games {
+		if strings.EqualFold(g.Developer, p.Name) {
+			n++
+		}
+	}
+	return n
+}
+
+// ManagePeople lists the people and studios in the registry and lets the user
+// edit their names, aliases and roles, merge duplicates and delete unused
+// entries.
+func ManagePeople() error {
+	cursor := choiceImport
+	for {
+		r, err := load()
+		if err != nil {
+			return err
+		}
+
+		options := make([]huh.Option[int], 0, len(r.people)+2)
+		for _, p := range r.people {
+			options = append(options, huh.NewOption(personLabel(r, p), int(p.ID)))
+		}
+		options = append(options,
+			huh.NewOption("📥 Add authors and developers from the collections", choiceImport),
+			huh.NewOption("✅ Done", choiceDone),
+		)
+
+		err = forms.Run(huh.NewSelect[int]().
+			Title("People and studios:").
+			Description(fmt.Sprintf("%d in the registry. Pick one to edit, merge or delete it.", len(r.people))).
+			Options(options...).
+			Value(&cursor).
+			Height(min(len(options)+2, 16)))
+		if err != nil {
+			return fmt.Errorf("person selection cancelled or failed: %w", err)
+		}
+
+		switch cursor {
+		case choiceDone:
+			return nil
+		case choiceImport:
+			err = importPeople(r)
+		default:
+			err = manageOne(r, uint32(cursor))
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func personLabel(r *registry, p models.Person) string {
+	label := p.Name
+	if len(p.Roles) > 0 {
+		roles := make([]string, len(p.Roles))
+		for i, role := range p.Roles {
+			roles[i] = string(role)
+		}
+		label += " · " + strings.Join(roles, ", ")
+	}
+	return fmt.Sprintf("%s · %d credit(s)", label, r.credits(p))
+}
+
+// importPeople links the free-text authors and developers of the collections
+// to the registry, adding the ones it does not know yet.
+func importPeople(r *registry) error {
+	before := len(r.people)
+	linked := 0
+	for i := range r.books {
+		if utils.LinkBookPeople(&r.people, &r.books[i]) {
+			linked++
+		}
+	}
+	for i := range r.games {
+		if utils.LinkGamePeople(&r.people, &r.games[i]) {
+			linked++
+		}
+	}
+	if err := r.save(); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Added %d people and linked %d entries.\n", len(r.people)-before, linked)
+	return nil
+}
+
+func manageOne(r *registry, id uint32) error {
+	i := slices.IndexFunc(r.people, func(p models.Person) bool { return p.ID == id })
+	if i < 0 {
+		return fmt.Errorf("internal error: selected person %d not found", id)
+	}
+
+	options := []huh.Option[personAction]{huh.NewOption("Edit name, aliases and roles", editPerson)}
+	if len(r.people) > 1 {
+		options = append(options, huh.NewOption("Merge into another person", mergePerson))
+	}
+	options = append(options,
+		huh.NewOption("Delete", deletePerson),
+		huh.NewOption("Back", back),
+	)
+
+	action := editPerson
+	err := forms.Run(huh.NewSelect[personAction]().
+		Title(personLabel(r, r.people[i])).
+		Options(options...).
+		Value(&action))
+	if err != nil {
+		return fmt.Errorf("person action cancelled or failed: %w", err)
+	}
+
+	switch action {
+	case editPerson:
+		return edit(r, i)
+	case mergePerson:
+		return merge(r, i)
+	case deletePerson:
+		return remove(r, i)
+	}
+	return nil
+}
+
+func edit(r *registry, i int) error {
+	p := &r.people[i]
+	oldName := p.Name
+	name := p.Name
+	aliases := strings.Join(p.Aliases, ", ")
+	roles := slices.Clone(p.Roles)
+
+	options := make([]huh.Option[models.Role], len(utils.Roles))
+	for j, role := range utils.Roles {
+		options[j] = huh.NewOption(string(role), role)
+	}
+
+	err := forms.NewForm(huh.NewGroup(
+		huh.NewInput().
+			Title("Name:").
+			Value(&name).
+			Validate(func(s string) error {
+				if strings.TrimSpace(s) == "" {
+					return fmt.Errorf("name cannot be empty")
+				}
+				if j := utils.FindPerson(r.people, s); j >= 0 && j != i {
+					return fmt.Errorf("%q is already a spelling of %s", strings.TrimSpace(s), r.people[j].Name)
+				}
+				return nil
+			}),
+		huh.NewInput().
+			Title("Aliases:").
+			Description("Other spellings, comma-separated, e.g. B. Sanderson.").
+			Value(&aliases),
+		huh.NewMultiSelect[models.Role]().
+			Title("Roles:").
+			Options(options...).
+			Value(&roles),
+	)).Run()
+	if err != nil {
+		return fmt.Errorf("form input error for person: %w", err)
+	}
+
+	p.Name = strings.TrimSpace(name)
+	p.Aliases = utils.MergeStrings(utils.SplitList(aliases))
+	p.Roles = roles
+
+	for j := range r.games {
+		if strings.EqualFold(r.games[j].Developer, oldName) {
+			r.games[j].Developer = p.Name
+		}
+	}
+	for j := range r.books {
+		if line := utils.AuthorLine(r.people, r.books[j].Contributors); line != "" {
+			r.books[j].Author = line
+		}
+	}
+
+	if err := r.save(); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Saved %s.\n", p.Name)
+	return nil
+}
+
+func merge(r *registry, i int) error {
+	source := r.people[i]
+
+	var options []huh.Option[uint32]
+	for _, p := range r.people {
+		if p.ID != source.ID {
+			options = append(options, huh.NewOption(personLabel(r, p), p.ID))
+		}
+	}
+
+	var into uint32
+	err := forms.Run(huh.NewSelect[uint32]().
+		Title(fmt.Sprintf("Merge %s into:", source.Name)).
+		Description(fmt.Sprintf("%s and its aliases become aliases of the person picked, which takes over its credits.", source.Name)).
+		Options(options...).
+		Value(&into))
+	if err != nil {
+		return fmt.Errorf("person selection cancelled or failed: %w", err)
+	}
+
+	r.people = utils.MergePeople(r.people, r.books, r.games, source.ID, into)
+	if err := r.save(); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Merged %s into %s.\n", source.Name, utils.PersonName(r.people, into))
+	return nil
+}
+
+// remove deletes a person nothing credits any more.
+func remove(r *registry, i int) error {
+	p := r.people[i]
+	if n := r.credits(p); n > 0 {
+		fmt.Printf("%s is credited on %d entries; merge them into another person instead.\n", p.Name, n)
+		return nil
+	}
+
+	var confirm bool
+	err := forms.Run(huh.NewConfirm().
+		Title(fmt.Sprintf("Delete %s?", p.Name)).
+		Value(&confirm))
+	if err != nil || !confirm {
+		return err
+	}
+
+	r.people = slices.Delete(r.people, i, i+1)
+	if err := r.save(); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Deleted %s.\n", p.Name)
+	return nil
+}
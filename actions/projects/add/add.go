@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"utilodactyl/models"
+	"utilodactyl/ui/forms"
 	"utilodactyl/utils"
 
 	"github.com/charmbracelet/huh"
@@ -13,7 +14,11 @@ import (
 func AddProject() error {
 	projects, err := utils.LoadProjects()
 	if err != nil {
-		return fmt.Errorf("error loading projects: %v", err)
+		return fmt.Errorf("error loading projects: %w", err)
+	}
+	cfg, err := utils.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
 	var newProject models.Project
@@ -36,75 +41,31 @@ func AddProject() error {
 		huh.NewInput().Title("Install Command:").Value(&newProject.InstallCommand),
 	)
 
-	if err = huh.NewForm(basicDetailsGroup).Run(); err != nil {
-		return fmt.Errorf("error creating new form: %v", err)
+	groups := append([]*huh.Group{basicDetailsGroup}, forms.PrivacyGroups(utils.ProjectPrivateFields, cfg.Projects.PrivateFields, &newProject.Private, &newProject.PrivateFields)...)
+	groups = append(groups, forms.ScheduleGroup(&newProject.Draft, &newProject.PublishAt))
+	if err = forms.NewForm(groups...).Run(); err != nil {
+		return fmt.Errorf("error creating new form: %w", err)
 	}
 
-	if err = handleTags(projects, &newProject); err != nil {
-		return fmt.Errorf("error handling tags: %v", err)
-	}
-
-	projects = append(projects, newProject)
-	if err = utils.SaveProjects(projects); err != nil {
-		return fmt.Errorf("error saving projects: %v", err)
+	tags := forms.NewStringSet("tags", utils.CollectUniqueProjectTags(projects), &newProject.Tags)
+	if err = tags.Run(); err != nil {
+		return fmt.Errorf("error handling tags: %w", err)
 	}
 
-	fmt.Println("Projects saved")
-	return nil
-}
-
-func handleTags(existingProjects []models.Project, project *models.Project) error {
-	existingTags := utils.CollectUniqueProjectTags(existingProjects)
-	if len(existingTags) > 0 {
-		err := huh.NewMultiSelect[string]().
-			Title("Select existing tags:").
-			Options(huh.NewOptions(existingTags...)...).
-			Value(&project.Tags).
-			Run()
-		if err != nil {
-			return err
-		}
+	if newProject.Links, err = forms.EditLinks(nil); err != nil {
+		return fmt.Errorf("error handling links: %w", err)
 	}
 
-	var confirmAddTag bool
-	err := huh.NewConfirm().
-		Title("Add custom tags?").
-		Value(&confirmAddTag).
-		Run()
+	newProject.ID, err = utils.GenerateProjectID()
 	if err != nil {
-		return err
+		return fmt.Errorf("error generating project ID: %w", err)
 	}
 
-	if confirmAddTag {
-		for {
-			var customTag string
-			err = huh.NewInput().
-				Title("New tag:").
-				Value(&customTag).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("tag cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			project.Tags = append(project.Tags, customTag)
-
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another tag?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
+	projects = append(projects, newProject)
+	if err = utils.SaveProjects(projects); err != nil {
+		return fmt.Errorf("error saving projects: %v", err)
 	}
+
+	fmt.Println("Projects saved")
 	return nil
 }
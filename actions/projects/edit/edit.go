@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"utilodactyl/models"
+	"utilodactyl/ui/forms"
 	"utilodactyl/utils"
 
 	"github.com/charmbracelet/huh"
@@ -13,41 +14,57 @@ import (
 func EditProject() error {
 	projects, err := utils.LoadProjects()
 	if err != nil {
-		return fmt.Errorf("error loading projects: %v", err)
+		return fmt.Errorf("error loading projects: %w", err)
 	}
 
 	if len(projects) == 0 {
 		return fmt.Errorf("no projects loaded")
 	}
 
-	projectNames := make([]string, len(projects))
-	for i, project := range projects {
-		projectNames[i] = project.Name
+	candidates, err := forms.FilterPrompt(projects, "projects")
+	if err != nil {
+		return fmt.Errorf("error filtering projects: %w", err)
+	}
+
+	projectOptions := make([]huh.Option[uint32], len(candidates))
+	for i, project := range candidates {
+		projectOptions[i] = huh.NewOption(utils.ProjectLabel(project), project.ID)
 	}
 
-	var selectedName string
-	err = huh.NewSelect[string]().
+	var selectedID uint32
+	err = forms.Run(huh.NewSelect[uint32]().
 		Title("Choose a project to edit:").
-		Options(huh.NewOptions(projectNames...)...).
-		Value(&selectedName).
-		Run()
+		Options(projectOptions...).
+		Value(&selectedID))
+	if err != nil {
+		return fmt.Errorf("error loading projects: %w", err)
+	}
+
+	return EditProjectByID(selectedID)
+}
+
+// EditProjectByID opens the edit form for the project with the given ID.
+func EditProjectByID(id uint32) error {
+	projects, err := utils.LoadProjects()
 	if err != nil {
-		return fmt.Errorf("error loading projects: %v", err)
+		return fmt.Errorf("error loading projects: %w", err)
 	}
 
-	var projToEdit *models.Project
 	for i := range projects {
-		if projects[i].Name == selectedName {
-			projToEdit = &projects[i]
-			break
+		if projects[i].ID == id {
+			return editProject(projects, &projects[i])
 		}
 	}
+	return fmt.Errorf("project with id %d not found", id)
+}
 
-	if projToEdit == nil {
-		return fmt.Errorf("project not found")
+func editProject(projects []models.Project, projToEdit *models.Project) error {
+	cfg, err := utils.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	basicDetailsForm := huh.NewForm(
+	groups := []*huh.Group{
 		huh.NewGroup(
 			huh.NewInput().Title("Name:").Value(&projToEdit.Name).Validate(func(s string) error {
 				if strings.TrimSpace(s) == "" {
@@ -74,78 +91,30 @@ func EditProject() error {
 				return nil
 			}),
 		),
-	)
-
-	if err = basicDetailsForm.Run(); err != nil {
-		return fmt.Errorf("error loading projects: %v", err)
 	}
+	groups = append(groups, forms.PrivacyGroups(utils.ProjectPrivateFields, cfg.Projects.PrivateFields, &projToEdit.Private, &projToEdit.PrivateFields)...)
+	groups = append(groups, forms.ScheduleGroup(&projToEdit.Draft, &projToEdit.PublishAt))
+	basicDetailsForm := forms.NewForm(groups...)
 
-	if err = editTags(projects, projToEdit); err != nil {
-		return fmt.Errorf("error loading projects: %v", err)
+	if err := basicDetailsForm.Run(); err != nil {
+		return fmt.Errorf("error loading projects: %w", err)
 	}
 
-	if err = utils.SaveProjects(projects); err != nil {
-		return fmt.Errorf("error saving projects: %v", err)
-	}
-
-	fmt.Println("✅ Project updated successfully!")
-	return nil
-}
-
-func editTags(allBooks []models.Project, project *models.Project) error {
-	existingTags := utils.CollectUniqueProjectTags(allBooks)
-	if len(existingTags) > 0 {
-		selectedTags := project.Tags
-		err := huh.NewMultiSelect[string]().
-			Title("Select/Deselect existing tags:").
-			Options(huh.NewOptions(existingTags...)...).
-			Value(&selectedTags).
-			Run()
-		if err != nil {
-			return err
-		}
-		project.Tags = selectedTags
+	tags := forms.NewStringSet("tags", utils.CollectUniqueProjectTags(projects), &projToEdit.Tags)
+	if err := tags.Run(); err != nil {
+		return fmt.Errorf("error editing project tags: %w", err)
 	}
 
-	var confirmAddTag bool
-	err := huh.NewConfirm().
-		Title("Add custom tags?").
-		Value(&confirmAddTag).
-		Run()
+	links, err := forms.EditLinks(projToEdit.Links)
 	if err != nil {
-		return err
+		return fmt.Errorf("error editing project links: %w", err)
 	}
+	projToEdit.Links = links
 
-	if confirmAddTag {
-		for {
-			var customTag string
-			err = huh.NewInput().
-				Title("New tag:").
-				Value(&customTag).
-				Validate(func(s string) error {
-					if strings.TrimSpace(s) == "" {
-						return fmt.Errorf("tag cannot be empty")
-					}
-					return nil
-				}).
-				Run()
-			if err != nil {
-				return err
-			}
-			project.Tags = append(project.Tags, customTag)
-
-			var addAnother bool
-			err = huh.NewConfirm().
-				Title("Add another tag?").
-				Value(&addAnother).
-				Run()
-			if err != nil {
-				return err
-			}
-			if !addAnother {
-				break
-			}
-		}
+	if err := utils.SaveProjects(projects); err != nil {
+		return fmt.Errorf("error saving projects: %v", err)
 	}
+
+	fmt.Println("✅ Project updated successfully!")
 	return nil
 }
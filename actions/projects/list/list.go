@@ -0,0 +1,37 @@
+// Package list
+package list
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"utilodactyl/query"
+	"utilodactyl/utils"
+)
+
+// ListProjects prints the projects matching expr, one per line. An empty expr
+// lists every project.
+func ListProjects(expr string) error {
+	projects, err := utils.LoadProjects()
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	projects, err = query.Filter(projects, expr)
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	if len(projects) == 0 {
+		fmt.Println("No matching projects.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tTAGS\tSOURCE")
+	for _, p := range projects {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", p.ID, p.Name, strings.Join(p.Tags, ", "), p.Source)
+	}
+	return w.Flush()
+}
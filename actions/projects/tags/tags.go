@@ -0,0 +1,20 @@
+package tags
+
+import (
+	"utilodactyl/actions/tags"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+)
+
+// ManageProjectTags cleans up the tags used across all projects.
+func ManageProjectTags() error {
+	return tags.Manage(tags.Collection[models.Project]{
+		Noun:  "project",
+		Load:  utils.LoadProjects,
+		Save:  utils.SaveProjects,
+		Label: func(p models.Project) string { return p.Name },
+		Fields: []tags.Field[models.Project]{
+			{Name: "tags", Get: func(p *models.Project) *[]string { return &p.Tags }},
+		},
+	})
+}
@@ -2,29 +2,101 @@ package view
 
 import (
 	"fmt"
-	"utilodactyl/utils"
 	"strings"
+	"time"
+	"utilodactyl/actions/projects/edit"
+	"utilodactyl/models"
+	"utilodactyl/query"
+	"utilodactyl/ui/browser"
+	"utilodactyl/utils"
 )
 
 func ViewProjects() error {
-	projects, err := utils.LoadProjects()
-	if err != nil {
-		return fmt.Errorf("failed to load projects: %w", err)
+	for {
+		projects, err := utils.LoadProjects()
+		if err != nil {
+			return fmt.Errorf("failed to load projects: %w", err)
+		}
+
+		if len(projects) == 0 {
+			fmt.Println("No projects found")
+			return nil
+		}
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		picked, err := browser.Run(browser.Config{
+			Title: "Projects",
+			Len:   len(projects),
+			Columns: []browser.Column{
+				{
+					Title: "ID", Width: 4,
+					Value: func(i int) string { return fmt.Sprint(projects[i].ID) },
+					Less:  func(a, b int) bool { return projects[a].ID < projects[b].ID },
+				},
+				{Title: "Name", Width: 24, Value: func(i int) string { return projects[i].Name }},
+				{Title: "Tags", Width: 30, Value: func(i int) string { return joinStringSlice(projects[i].Tags, ", ") }},
+			},
+			Detail: func(i int) string { return projectDetail(projects[i], cfg.Projects.PrivateFields) },
+			Filter: func(expr string) (func(i int) bool, error) {
+				match, err := query.Compile[models.Project](expr)
+				if err != nil {
+					return nil, err
+				}
+				return func(i int) bool { return match(&projects[i]) }, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if picked < 0 {
+			return nil
+		}
+
+		if err := edit.EditProjectByID(projects[picked].ID); err != nil {
+			fmt.Printf("Error editing project: %v\n", err)
+		}
 	}
+}
 
-	if len(projects) == 0 {
-		fmt.Println("No projects found")
-	} else {
-		for _, project := range projects {
-			fmt.Printf("\nName: %s\n", project.Name)
-			fmt.Printf("\nDescription: %s\n", project.Description)
-			fmt.Printf("\nTags: %v\n", joinStringSlice(project.Tags, ", "))
-			fmt.Printf("\nSource Repo: %s\n", project.Source)
-			fmt.Printf("\nInstall Command: %s\n", project.InstallCommand)
+// projectDetail formats every field of a project for the detail pane. Fields
+// left out of the public release are marked with a lock; private is the list
+// the collection keeps private.
+func projectDetail(project models.Project, private []string) string {
+	hidden := utils.Unpublished(private, project.PrivateFields)
+	lock := func(field string) string {
+		if hidden[field] && !project.Private {
+			return " 🔒"
 		}
+		return ""
 	}
 
-	return nil
+	var b strings.Builder
+	if project.Private {
+		b.WriteString("🔒 Private: this project is not published\n")
+	} else if len(hidden) > 0 {
+		b.WriteString("🔒 marks fields that are not published\n")
+	}
+	if project.Draft {
+		b.WriteString("Draft: not published until the draft flag is cleared\n")
+	}
+	if project.PublishAt != nil && project.PublishAt.After(time.Now()) {
+		fmt.Fprintf(&b, "Scheduled: published from %s\n", utils.FormatPublishAt(project.PublishAt))
+	}
+	fmt.Fprintf(&b, "Name: %s\n", project.Name)
+	fmt.Fprintf(&b, "\nDescription%s: %s\n", lock("description"), project.Description)
+	fmt.Fprintf(&b, "\nTags%s: %v\n", lock("tags"), joinStringSlice(project.Tags, ", "))
+	fmt.Fprintf(&b, "\nSource Repo%s: %s\n", lock("source"), project.Source)
+	fmt.Fprintf(&b, "\nInstall Command%s: %s\n", lock("installCommand"), project.InstallCommand)
+	if len(project.Links) > 0 {
+		fmt.Fprintf(&b, "\nLinks%s:\n", lock("links"))
+		for _, link := range project.Links {
+			fmt.Fprintf(&b, "  • %s → %s\n", link.Title, link.URL)
+		}
+	}
+	return b.String()
 }
 
 // joinStringSlice concatenates a slice of strings into a single string,
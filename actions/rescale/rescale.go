@@ -0,0 +1,161 @@
+// Package rescale converts a collection's ratings to another scale.
+package rescale
+
+import (
+	"errors"
+	"fmt"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+)
+
+// Rescale converts every rating of the named collection to the given scale and
+// records the new scale in the settings file. Nothing is changed unless every
+// rating has an exact equivalent on the new scale.
+func Rescale(collection string, to models.RatingScale) error {
+	cfg, err := utils.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	target, err := utils.FindScale(to)
+	if err != nil {
+		return err
+	}
+
+	var current *models.RatingScale
+	var convert func(from, to utils.Scale) (int, func() error, error)
+	switch collection {
+	case "books":
+		current, convert = &cfg.Books.RatingScale, rescaleBooks
+	case "games":
+		current, convert = &cfg.Games.RatingScale, rescaleGames
+	case "reviews":
+		current, convert = &cfg.Reviews.RatingScale, rescaleReviews
+	default:
+		return fmt.Errorf("%s have no ratings to rescale", collection)
+	}
+
+	from, err := utils.FindScale(*current)
+	if err != nil {
+		return err
+	}
+	if from.Name == target.Name {
+		fmt.Printf("The %s are already rated on the %s scale.\n", collection, target.Name)
+		return nil
+	}
+
+	n, write, err := convert(from, target)
+	if err != nil {
+		return fmt.Errorf("cannot rescale %s from %s to %s without losing precision; nothing was changed:\n%w",
+			collection, from.Name, target.Name, err)
+	}
+
+	// The settings go first: if writing the collection then fails, they can be
+	// put back, while ratings written on a scale the settings do not name
+	// would all read as out of range.
+	previous := *current
+	*current = target.Name
+	if err := utils.SaveConfig(cfg); err != nil {
+		return err
+	}
+	if err := write(); err != nil {
+		*current = previous
+		if rerr := utils.SaveConfig(cfg); rerr != nil {
+			return fmt.Errorf("%w; putting the %s scale back in the settings also failed: %v", err, from.Name, rerr)
+		}
+		return err
+	}
+	fmt.Printf("✅ Converted %d rating(s) of %s from the %s scale to the %s scale.\n", n, collection, from.Name, target.Name)
+	return nil
+}
+
+// convertAll converts the rating of each item, collecting a failure for every
+// rating that cannot be converted exactly. It returns how many ratings were
+// set.
+func convertAll[T any](items []T, rating func(*T) *models.Rating, label func(T) string, from, to utils.Scale) (int, error) {
+	var errs []error
+	converted := make([]models.Rating, len(items))
+	n := 0
+	for i := range items {
+		r := *rating(&items[i])
+		c, err := utils.Convert(r, from, to)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", label(items[i]), err))
+			continue
+		}
+		converted[i] = c
+		if r != 0 {
+			n++
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return 0, err
+	}
+	for i := range items {
+		*rating(&items[i]) = converted[i]
+	}
+	return n, nil
+}
+
+// rescaleBooks converts the books' ratings without writing them, returning
+// how many ratings were set and a function that writes the books. They are
+// written without being stamped as updated, since the ratings still say the
+// same. rescaleGames and rescaleReviews do the same for their collections.
+func rescaleBooks(from, to utils.Scale) (int, func() error, error) {
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to load books: %w", err)
+	}
+	n, err := convertAll(books, func(b *models.Book) *models.Rating { return &b.Rating }, utils.BookLabel, from, to)
+	if err != nil {
+		return 0, nil, err
+	}
+	return n, func() error {
+		if err := utils.RewriteBooks(books); err != nil {
+			return fmt.Errorf("failed to save books: %w", err)
+		}
+		return nil
+	}, nil
+}
+
+func rescaleGames(from, to utils.Scale) (int, func() error, error) {
+	games, err := utils.LoadGames()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to load games: %w", err)
+	}
+	n, err := convertAll(games, func(g *models.Game) *models.Rating { return &g.Rating }, utils.GameLabel, from, to)
+	if err != nil {
+		return 0, nil, err
+	}
+	return n, func() error {
+		if err := utils.RewriteGames(games); err != nil {
+			return fmt.Errorf("failed to save games: %w", err)
+		}
+		return nil
+	}, nil
+}
+
+func rescaleReviews(from, to utils.Scale) (int, func() error, error) {
+	reviews, err := utils.LoadReviews()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to load reviews: %w", err)
+	}
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to load books: %w", err)
+	}
+	works, err := utils.LoadWorks()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to load works: %w", err)
+	}
+	label := func(r models.Review) string { return utils.ReviewLabel(books, works, r) }
+	n, err := convertAll(reviews, func(r *models.Review) *models.Rating { return &r.Rating }, label, from, to)
+	if err != nil {
+		return 0, nil, err
+	}
+	return n, func() error {
+		if err := utils.SaveReviews(reviews); err != nil {
+			return fmt.Errorf("failed to save reviews: %w", err)
+		}
+		return nil
+	}, nil
+}
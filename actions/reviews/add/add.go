@@ -3,9 +3,9 @@ package add
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 	"utilodactyl/models"
+	"utilodactyl/ui/forms"
 	"utilodactyl/utils"
 
 	"github.com/charmbracelet/huh"
@@ -17,37 +17,38 @@ func AddReview() error {
 		return fmt.Errorf("error loading reviews: %v", err)
 	}
 
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return fmt.Errorf("error loading books: %w", err)
+	}
+
+	works, err := utils.LoadWorks()
+	if err != nil {
+		return fmt.Errorf("error loading works: %w", err)
+	}
+
+	scale, err := utils.ReviewScale()
+	if err != nil {
+		return fmt.Errorf("error loading review rating scale: %w", err)
+	}
+
 	var newReview models.Review
 
-	newReview.Chapter, err = utils.GenerateReviewID()
+	newReview.ID, err = utils.GenerateReviewID()
 	if err != nil {
 		return fmt.Errorf("error generating review ID: %v", err)
 	}
 
-	basicDetailsGroup := huh.NewGroup(
-		huh.NewInput().
-			Title("Chapter Number:").
-			Value(new(string)).
-			Validate(func(s string) error {
-				chapterStr := strings.TrimSpace(s)
-				if chapterStr == "" {
-					return fmt.Errorf("chapter number cannot be empty")
-				}
-				chapter, err := parseUint32(chapterStr)
-				if err != nil {
-					return fmt.Errorf("invalid chapter number: %v", err)
-				}
-				if chapter == 0 {
-					return fmt.Errorf("chapter number must be greater than 0")
-				}
-				for _, r := range reviews {
-					if r.Chapter == chapter {
-						return fmt.Errorf("chapter number %d already exists", chapter)
-					}
-				}
-				newReview.Chapter = chapter 
-				return nil
-			}),
+	knownWorks := len(works)
+	work, err := forms.PickWork(books, &works, utils.WorkRef{})
+	if err != nil {
+		return fmt.Errorf("error picking the reviewed work: %w", err)
+	}
+	utils.SetReviewWork(&newReview, work)
+	next := utils.NextChapter(reviews, work)
+	newReview.Volume, newReview.Chapter = next.Volume, next.Chapter
+
+	basicDetailsGroup := huh.NewGroup(append(forms.ChapterFields(reviews, &newReview),
 		huh.NewInput().
 			Title("Description:").
 			Value(&newReview.Description).
@@ -57,24 +58,7 @@ func AddReview() error {
 				}
 				return nil
 			}),
-		huh.NewInput().
-			Title("Rating (1-5):").
-			Value(new(string)).
-			Validate(func(s string) error {
-				ratingStr := strings.TrimSpace(s)
-				if ratingStr == "" {
-					return fmt.Errorf("rating cannot be empty")
-				}
-				rating, err := parseUint8(ratingStr)
-				if err != nil {
-					return fmt.Errorf("invalid rating: %v", err)
-				}
-				if rating < 1 || rating > 5 {
-					return fmt.Errorf("rating must be between 1 and 5")
-				}
-				newReview.Rating = rating
-				return nil
-			}),
+		forms.RatingField(scale, &newReview.Rating),
 		huh.NewInput().
 			Title("Thoughts:").
 			Value(&newReview.Thoughts).
@@ -84,10 +68,16 @@ func AddReview() error {
 				}
 				return nil
 			}),
-	)
+	)...)
+
+	if err = forms.NewForm(basicDetailsGroup, forms.ScheduleGroup(&newReview.Draft, &newReview.PublishAt)).Run(); err != nil {
+		return fmt.Errorf("error creating new review form: %w", err)
+	}
 
-	if err = huh.NewForm(basicDetailsGroup).Run(); err != nil {
-		return fmt.Errorf("error creating new review form: %v", err)
+	if len(works) > knownWorks {
+		if err = utils.SaveWorks(works); err != nil {
+			return fmt.Errorf("error saving works: %w", err)
+		}
 	}
 
 	reviews = append(reviews, newReview)
@@ -95,26 +85,6 @@ func AddReview() error {
 		return fmt.Errorf("error saving reviews: %v", err)
 	}
 
-	fmt.Printf("✅ Review for Chapter %d saved successfully!\n", newReview.Chapter)
+	fmt.Printf("✅ Review for %s, %s saved successfully!\n", utils.WorkLabel(books, works, work), utils.FormatChapter(newReview))
 	return nil
 }
-
-func parseUint8(s string) (uint8, error) {
-	var i int
-	_, err := fmt.Sscanf(s, "%d", &i)
-	if err != nil {
-		return 0, err
-	}
-	if i < 0 || i > 255 {
-		return 0, fmt.Errorf("value out of uint8 range")
-	}
-	return uint8(i), nil
-}
-
-func parseUint32(s string) (uint32, error) {
-	val, err := strconv.ParseUint(s, 10, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse to number: %w", err)
-	}
-	return uint32(val), nil
-}
@@ -0,0 +1,254 @@
+// Package arcs manages the story arcs that group the chapter reviews of a
+// work.
+package arcs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"utilodactyl/models"
+	"utilodactyl/ui/forms"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// Special choices in the arc list, kept negative so they never collide with
+// an arc ID.
+const (
+	choiceNew  = -1
+	choiceDone = -2
+)
+
+type arcAction int
+
+const (
+	editArc arcAction = iota
+	deleteArc
+	back
+)
+
+// collection is everything the arc manager reads.
+type collection struct {
+	arcs    []models.Arc
+	reviews []models.Review
+	books   []models.Book
+	works   []models.Work
+	scale   utils.Scale
+}
+
+func load() (*collection, error) {
+	arcs, err := utils.LoadArcs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load arcs: %w", err)
+	}
+	reviews, err := utils.LoadReviews()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reviews: %w", err)
+	}
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load books: %w", err)
+	}
+	works, err := utils.LoadWorks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load works: %w", err)
+	}
+	scale, err := utils.ReviewScale()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load review rating scale: %w", err)
+	}
+	return &collection{arcs: arcs, reviews: reviews, books: books, works: works, scale: scale}, nil
+}
+
+func (c *collection) label(a models.Arc) string {
+	label := utils.ArcLabel(c.books, c.works, a)
+	if stats, ok := utils.ComputeArcStats(a, c.reviews); ok {
+		return label + " · " + utils.FormatArcStats(stats, c.scale)
+	}
+	return label + " · no reviews yet"
+}
+
+// ManageArcs lists the story arcs of the reviewed works with their average
+// rating, trend and best and worst chapters, and lets the user add, edit and
+// delete them.
+func ManageArcs() error {
+	cursor := choiceNew
+	for {
+		c, err := load()
+		if err != nil {
+			return err
+		}
+
+		arcs := utils.GroupArcsByWork(c.arcs, c.books, c.works)
+		options := make([]huh.Option[int], 0, len(arcs)+2)
+		for _, a := range arcs {
+			options = append(options, huh.NewOption(c.label(a), int(a.ID)))
+		}
+		options = append(options,
+			huh.NewOption("➕ New arc", choiceNew),
+			huh.NewOption("✅ Done", choiceDone),
+		)
+
+		err = forms.Run(huh.NewSelect[int]().
+			Title("Story arcs:").
+			Description(fmt.Sprintf("%d arc(s). Pick one to edit or delete it.", len(arcs))).
+			Options(options...).
+			Value(&cursor).
+			Height(min(len(options)+2, 16)))
+		if err != nil {
+			return fmt.Errorf("arc selection cancelled or failed: %w", err)
+		}
+
+		switch cursor {
+		case choiceDone:
+			return nil
+		case choiceNew:
+			var id uint32
+			if id, err = newArc(c); err == nil {
+				cursor = int(id)
+			}
+		default:
+			err = manageOne(c, uint32(cursor))
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func newArc(c *collection) (uint32, error) {
+	id, err := utils.GenerateArcID()
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate unique arc ID: %w", err)
+	}
+	c.arcs = append(c.arcs, models.Arc{ID: id})
+	if err := edit(c, len(c.arcs)-1); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func manageOne(c *collection, id uint32) error {
+	i := slices.IndexFunc(c.arcs, func(a models.Arc) bool { return a.ID == id })
+	if i < 0 {
+		return fmt.Errorf("internal error: selected arc %d not found", id)
+	}
+
+	action := editArc
+	err := forms.Run(huh.NewSelect[arcAction]().
+		Title(c.label(c.arcs[i])).
+		Options(
+			huh.NewOption("Edit", editArc),
+			huh.NewOption("Delete", deleteArc),
+			huh.NewOption("Back", back),
+		).
+		Value(&action))
+	if err != nil {
+		return fmt.Errorf("arc action cancelled or failed: %w", err)
+	}
+
+	switch action {
+	case editArc:
+		return edit(c, i)
+	case deleteArc:
+		return remove(c, i)
+	}
+	return nil
+}
+
+// edit asks for the work, name and chapter range of the i-th arc and saves it.
+func edit(c *collection, i int) error {
+	a := &c.arcs[i]
+	knownWorks := len(c.works)
+	work, err := forms.PickWork(c.books, &c.works, utils.ArcWork(*a))
+	if err != nil {
+		return fmt.Errorf("error picking the arc's work: %w", err)
+	}
+	utils.SetArcWork(a, work)
+	if a.First == (models.ChapterPoint{}) {
+		a.First = utils.ChapterPointOf(utils.NextChapter(nil, work))
+	}
+	if a.Last == (models.ChapterPoint{}) {
+		a.Last = a.First
+	}
+
+	err = forms.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Arc name:").
+				Value(&a.Name).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("arc name cannot be empty")
+					}
+					return nil
+				}),
+		),
+		huh.NewGroup(forms.PointFields("First chapter:", &a.First, func(p models.ChapterPoint) error {
+			candidate := *a
+			candidate.First, candidate.Last = p, p
+			if other, ok := utils.ArcOverlap(c.arcs, candidate); ok {
+				return fmt.Errorf("%s is part of %s (%s)", utils.FormatPoint(p), other.Name, utils.ArcRange(other))
+			}
+			return nil
+		})...).
+			Title("Starts at"),
+		huh.NewGroup(forms.PointFields("Last chapter:", &a.Last, func(p models.ChapterPoint) error {
+			if utils.ComparePoints(a.First, p) > 0 {
+				return fmt.Errorf("the arc cannot end before %s", utils.FormatPoint(a.First))
+			}
+			candidate := *a
+			candidate.Last = p
+			if other, ok := utils.ArcOverlap(c.arcs, candidate); ok {
+				return fmt.Errorf("overlaps %s (%s)", other.Name, utils.ArcRange(other))
+			}
+			return nil
+		})...).
+			Title("Ends at"),
+	).Run()
+	if err != nil {
+		return fmt.Errorf("form input error for arc: %w", err)
+	}
+	a.Name = strings.TrimSpace(a.Name)
+
+	if len(c.works) > knownWorks {
+		if err := utils.SaveWorks(c.works); err != nil {
+			return fmt.Errorf("failed to save works: %w", err)
+		}
+	}
+	if err := c.save(); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Saved arc %s (%s).\n", a.Name, utils.ArcRange(*a))
+	return nil
+}
+
+func remove(c *collection, i int) error {
+	a := c.arcs[i]
+	var confirm bool
+	err := forms.Run(huh.NewConfirm().
+		Title(fmt.Sprintf("Delete the arc %s?", a.Name)).
+		Description("Its reviews are kept.").
+		Value(&confirm))
+	if err != nil || !confirm {
+		return err
+	}
+
+	c.arcs = slices.Delete(c.arcs, i, i+1)
+	if err := c.save(); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Deleted arc %s.\n", a.Name)
+	return nil
+}
+
+func (c *collection) save() error {
+	if err := utils.ValidateArcs(c.arcs, c.books, c.works); err != nil {
+		return fmt.Errorf("arcs would be invalid: %w", err)
+	}
+	if err := utils.SaveArcs(c.arcs); err != nil {
+		return fmt.Errorf("failed to save arcs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+// Package bulk reviews a range of chapters in one go.
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"utilodactyl/models"
+	"utilodactyl/ui/forms"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+type resumeChoice int
+
+const (
+	resume resumeChoice = iota
+	startOver
+	cancel
+)
+
+type stopChoice int
+
+const (
+	saveNow stopChoice = iota
+	keepForLater
+	discard
+)
+
+// BulkAddReviews walks through a range of chapters of a work, asking for a
+// review of each. Chapters left without a rating are skipped. The reviews are
+// saved together at the end; until then they are kept as a resume point, so an
+// interrupted entry carries on where it stopped.
+func BulkAddReviews() error {
+	reviews, err := utils.LoadReviews()
+	if err != nil {
+		return fmt.Errorf("error loading reviews: %w", err)
+	}
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return fmt.Errorf("error loading books: %w", err)
+	}
+	works, err := utils.LoadWorks()
+	if err != nil {
+		return fmt.Errorf("error loading works: %w", err)
+	}
+
+	session, err := utils.LoadBulkSession()
+	if err != nil {
+		return fmt.Errorf("error loading the resume point: %w", err)
+	}
+	if session != nil {
+		choice := resume
+		err := forms.Run(huh.NewSelect[resumeChoice]().
+			Title("A bulk entry was interrupted").
+			Description(fmt.Sprintf("%d review(s) of %s entered; next up is %s.",
+				len(session.Reviews), utils.WorkLabel(books, works, sessionWork(session)), utils.FormatPoint(session.Next))).
+			Options(
+				huh.NewOption("Resume it", resume),
+				huh.NewOption("Discard it and start over", startOver),
+				huh.NewOption("Cancel", cancel),
+			).
+			Value(&choice))
+		if err != nil {
+			return fmt.Errorf("resume selection cancelled or failed: %w", err)
+		}
+		switch choice {
+		case cancel:
+			return nil
+		case startOver:
+			if err := utils.ClearBulkSession(); err != nil {
+				return err
+			}
+			session = nil
+		}
+	}
+
+	if session == nil {
+		if session, err = newSession(reviews, books, &works); err != nil {
+			return err
+		}
+	}
+	return walk(reviews, books, works, session)
+}
+
+func sessionWork(s *models.BulkSession) utils.WorkRef {
+	return utils.WorkRef{BookID: s.BookID, WorkID: s.WorkID}
+}
+
+// newSession asks for the work, the chapter range and whether to enter
+// ratings only.
+func newSession(reviews []models.Review, books []models.Book, works *[]models.Work) (*models.BulkSession, error) {
+	knownWorks := len(*works)
+	ref, err := forms.PickWork(books, works, utils.WorkRef{})
+	if err != nil {
+		return nil, fmt.Errorf("error picking the reviewed work: %w", err)
+	}
+	if len(*works) > knownWorks {
+		if err := utils.SaveWorks(*works); err != nil {
+			return nil, fmt.Errorf("error saving works: %w", err)
+		}
+	}
+
+	session := &models.BulkSession{BookID: ref.BookID, WorkID: ref.WorkID}
+	next := utils.NextChapter(reviews, ref)
+	session.Next = models.ChapterPoint{Volume: next.Volume, Chapter: next.Chapter}
+	last := uint32(next.Chapter)
+
+	err = forms.NewForm(huh.NewGroup(
+		forms.NumberInput("Volume (optional):", &session.Next.Volume, nil).
+			Description("Leave empty for works without volumes."),
+		forms.ChapterInput("From chapter:", &session.Next, nil),
+		forms.NumberInput("To chapter:", &last, nil).
+			Validate(func(s string) error {
+				n, err := strconv.ParseUint(strings.TrimSpace(s), 10, 32)
+				if err != nil {
+					return fmt.Errorf("enter a whole number")
+				}
+				end := models.ChapterPoint{Volume: session.Next.Volume, Chapter: float64(n)}
+				if utils.ComparePoints(session.Next, end) > 0 {
+					return fmt.Errorf("the range cannot end before %s", utils.FormatPoint(session.Next))
+				}
+				return nil
+			}),
+		huh.NewConfirm().
+			Title("Ratings only?").
+			Description("Leave out the description and thoughts; they can be added later by editing each review.").
+			Value(&session.Compact),
+	).Title("Bulk review entry")).Run()
+	if err != nil {
+		return nil, fmt.Errorf("error creating bulk review form: %w", err)
+	}
+	session.Last = models.ChapterPoint{Volume: session.Next.Volume, Chapter: float64(last)}
+
+	if err := utils.SaveBulkSession(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// walk asks for a review of each chapter of the session's range, keeping the
+// resume point up to date after each one.
+func walk(reviews []models.Review, books []models.Book, works []models.Work, session *models.BulkSession) error {
+	ref := sessionWork(session)
+	workLabel := utils.WorkLabel(books, works, ref)
+	scale, err := utils.ReviewScale()
+	if err != nil {
+		return fmt.Errorf("error loading review rating scale: %w", err)
+	}
+
+	// Number the pending reviews after the saved ones, which may have changed
+	// since the entry was interrupted.
+	base, err := utils.GenerateReviewID()
+	if err != nil {
+		return fmt.Errorf("error generating review ID: %w", err)
+	}
+	for i := range session.Reviews {
+		session.Reviews[i].ID = base + uint32(i)
+	}
+
+	for utils.ComparePoints(session.Next, session.Last) <= 0 {
+		review := models.Review{ID: base + uint32(len(session.Reviews))}
+		utils.SetReviewWork(&review, ref)
+		point := session.Next
+		known := slices.Concat(reviews, session.Reviews)
+		var rating string
+
+		fields := []huh.Field{
+			forms.ChapterInput("Chapter:", &point, func(p models.ChapterPoint) error {
+				candidate := review
+				candidate.Volume, candidate.Label, candidate.Chapter = p.Volume, p.Label, p.Chapter
+				if utils.ChapterTaken(known, candidate) {
+					return fmt.Errorf("%s of this work is already reviewed", utils.FormatChapter(candidate))
+				}
+				return nil
+			}),
+			huh.NewInput().
+				Title("Rating (" + scale.Range() + "):").
+				Description("Leave empty to skip this chapter.").
+				Value(&rating).
+				Validate(func(s string) error {
+					_, err := parseRating(scale, s)
+					return err
+				}),
+		}
+		if !session.Compact {
+			required := func(what string) func(string) error {
+				return func(s string) error {
+					if strings.TrimSpace(rating) != "" && strings.TrimSpace(s) == "" {
+						return fmt.Errorf("%s cannot be empty", what)
+					}
+					return nil
+				}
+			}
+			fields = append(fields,
+				huh.NewInput().Title("Description:").Value(&review.Description).Validate(required("description")),
+				huh.NewInput().Title("Thoughts:").Value(&review.Thoughts).Validate(required("thoughts")),
+			)
+		}
+
+		err := forms.NewForm(huh.NewGroup(fields...).
+			Title(fmt.Sprintf("%s · up to %s", workLabel, utils.FormatPoint(session.Last))).
+			Description(fmt.Sprintf("%d review(s) entered. esc to stop.", len(session.Reviews))),
+		).Run()
+		if errors.Is(err, huh.ErrUserAborted) {
+			return stop(reviews, workLabel, session)
+		}
+		if err != nil {
+			return fmt.Errorf("error in bulk review form: %w", err)
+		}
+
+		if review.Rating, err = parseRating(scale, rating); err != nil {
+			return err
+		}
+		if review.Rating != 0 {
+			review.Volume, review.Label, review.Chapter = point.Volume, point.Label, point.Chapter
+			review.Description = strings.TrimSpace(review.Description)
+			review.Thoughts = strings.TrimSpace(review.Thoughts)
+			session.Reviews = append(session.Reviews, review)
+		}
+		session.Next = utils.ChapterAfter(session.Next, point)
+		if err := utils.SaveBulkSession(session); err != nil {
+			return err
+		}
+	}
+	return finish(reviews, workLabel, session)
+}
+
+// parseRating reads a rating on the scale; empty input is 0, for a skipped
+// chapter.
+func parseRating(scale utils.Scale, s string) (models.Rating, error) {
+	if strings.TrimSpace(s) == "" {
+		return 0, nil
+	}
+	return scale.Parse(s)
+}
+
+// stop asks what to do with the reviews entered so far when the walk is
+// stopped before the end of the range.
+func stop(reviews []models.Review, workLabel string, session *models.BulkSession) error {
+	if len(session.Reviews) == 0 {
+		return utils.ClearBulkSession()
+	}
+
+	choice := saveNow
+	err := forms.Run(huh.NewSelect[stopChoice]().
+		Title(fmt.Sprintf("Stopped before %s", utils.FormatPoint(session.Next))).
+		Options(
+			huh.NewOption(fmt.Sprintf("Save the %d review(s) entered", len(session.Reviews)), saveNow),
+			huh.NewOption("Keep them and resume later", keepForLater),
+			huh.NewOption("Discard them", discard),
+		).
+		Value(&choice))
+	if err != nil {
+		choice = keepForLater
+	}
+
+	switch choice {
+	case saveNow:
+		return finish(reviews, workLabel, session)
+	case discard:
+		return utils.ClearBulkSession()
+	}
+	fmt.Printf("Kept %d review(s) of %s; review a range of chapters again to resume.\n", len(session.Reviews), workLabel)
+	return nil
+}
+
+// finish saves the reviews entered and drops the resume point.
+func finish(reviews []models.Review, workLabel string, session *models.BulkSession) error {
+	if len(session.Reviews) > 0 {
+		if err := utils.SaveReviews(append(reviews, session.Reviews...)); err != nil {
+			return fmt.Errorf("error saving reviews: %w", err)
+		}
+	}
+	if err := utils.ClearBulkSession(); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Saved %d review(s) of %s.\n", len(session.Reviews), workLabel)
+	return nil
+}
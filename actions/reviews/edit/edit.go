@@ -3,9 +3,9 @@ package edit
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 	"utilodactyl/models"
+	"utilodactyl/ui/forms"
 	"utilodactyl/utils"
 
 	"github.com/charmbracelet/huh"
@@ -21,32 +21,68 @@ func EditReview() error {
 		return fmt.Errorf("no reviews found to edit")
 	}
 
-	reviewOptions := make([]huh.Option[string], len(reviews))
-	for i, review := range reviews {
-		reviewOptions[i] = huh.NewOption(fmt.Sprintf("Chapter %d: %s", review.Chapter, review.Description), strconv.Itoa(int(review.Chapter)))
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return fmt.Errorf("error loading books: %w", err)
+	}
+
+	works, err := utils.LoadWorks()
+	if err != nil {
+		return fmt.Errorf("error loading works: %w", err)
+	}
+
+	candidates, err := forms.FilterPrompt(utils.GroupReviewsByWork(reviews, books, works), "reviews")
+	if err != nil {
+		return fmt.Errorf("error filtering reviews: %w", err)
 	}
 
-	var selectedChapterStr string
-	err = huh.NewSelect[string]().
+	reviewOptions := make([]huh.Option[uint32], len(candidates))
+	for i, review := range candidates {
+		reviewOptions[i] = huh.NewOption(utils.ReviewLabel(books, works, review), review.ID)
+	}
+
+	var selectedID uint32
+	err = forms.Run(huh.NewSelect[uint32]().
 		Title("Choose a review to edit:").
 		Options(reviewOptions...).
-		Value(&selectedChapterStr).
-		Run()
+		Value(&selectedID))
 	if err != nil {
-		return fmt.Errorf("error selecting review: %v", err)
+		return fmt.Errorf("error selecting review: %w", err)
 	}
 
-	selectedChapter, err := strconv.ParseUint(selectedChapterStr, 10, 32)
+	return editReview(reviews, books, works, selectedID)
+}
+
+// EditReviewByID opens the edit form for the review with the given ID.
+func EditReviewByID(id uint32) error {
+	reviews, err := utils.LoadReviews()
 	if err != nil {
-		return fmt.Errorf("invalid chapter selected: %v", err)
+		return fmt.Errorf("error loading reviews: %v", err)
+	}
+
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return fmt.Errorf("error loading books: %w", err)
+	}
+
+	works, err := utils.LoadWorks()
+	if err != nil {
+		return fmt.Errorf("error loading works: %w", err)
+	}
+
+	return editReview(reviews, books, works, id)
+}
+
+func editReview(reviews []models.Review, books []models.Book, works []models.Work, id uint32) error {
+	scale, err := utils.ReviewScale()
+	if err != nil {
+		return fmt.Errorf("error loading review rating scale: %w", err)
 	}
 
 	var reviewToEdit *models.Review
-	var originalChapter uint32
 	for i := range reviews {
-		if reviews[i].Chapter == uint32(selectedChapter) {
+		if reviews[i].ID == id {
 			reviewToEdit = &reviews[i]
-			originalChapter = reviews[i].Chapter 
 			break
 		}
 	}
@@ -55,31 +91,15 @@ func EditReview() error {
 		return fmt.Errorf("review not found")
 	}
 
-	basicDetailsForm := huh.NewForm(
-		huh.NewGroup(
-			huh.NewInput().
-				Title("Chapter Number:").
-				Value(new(string)).
-				Validate(func(s string) error {
-					chapterStr := strings.TrimSpace(s)
-					if chapterStr == "" {
-						return fmt.Errorf("chapter number cannot be empty")
-					}
-					chapter, err := parseUint32(chapterStr)
-					if err != nil {
-						return fmt.Errorf("invalid chapter number: %v", err)
-					}
-					if chapter == 0 {
-						return fmt.Errorf("chapter number must be greater than 0")
-					}
-					for _, r := range reviews {
-						if r.Chapter == chapter && r.Chapter != originalChapter {
-							return fmt.Errorf("chapter number %d already exists", chapter)
-						}
-					}
-					reviewToEdit.Chapter = chapter
-					return nil
-				}),
+	knownWorks := len(works)
+	work, err := forms.PickWork(books, &works, utils.ReviewWork(*reviewToEdit))
+	if err != nil {
+		return fmt.Errorf("error picking the reviewed work: %w", err)
+	}
+	utils.SetReviewWork(reviewToEdit, work)
+
+	basicDetailsForm := forms.NewForm(
+		huh.NewGroup(append(forms.ChapterFields(reviews, reviewToEdit),
 			huh.NewInput().
 				Title("Description:").
 				Value(&reviewToEdit.Description).
@@ -89,24 +109,7 @@ func EditReview() error {
 					}
 					return nil
 				}),
-			huh.NewInput().
-				Title("Rating (1-5):").
-				Value(new(string)).
-				Validate(func(s string) error {
-					ratingStr := strings.TrimSpace(s)
-					if ratingStr == "" {
-						return fmt.Errorf("rating cannot be empty")
-					}
-					rating, err := parseUint8(ratingStr)
-					if err != nil {
-						return fmt.Errorf("invalid rating: %v", err)
-					}
-					if rating < 1 || rating > 5 {
-						return fmt.Errorf("rating must be between 1 and 5")
-					}
-					reviewToEdit.Rating = rating
-					return nil
-				}),
+			forms.RatingField(scale, &reviewToEdit.Rating),
 			huh.NewInput().
 				Title("Thoughts:").
 				Value(&reviewToEdit.Thoughts).
@@ -116,33 +119,24 @@ func EditReview() error {
 					}
 					return nil
 				}),
-		),
+		)...),
+		forms.ScheduleGroup(&reviewToEdit.Draft, &reviewToEdit.PublishAt),
 	)
 
-	if err = basicDetailsForm.Run(); err != nil {
-		return fmt.Errorf("error editing review form: %v", err)
+	if err := basicDetailsForm.Run(); err != nil {
+		return fmt.Errorf("error editing review form: %w", err)
 	}
 
-	if err = utils.SaveReviews(reviews); err != nil {
-		return fmt.Errorf("error saving reviews: %v", err)
+	if len(works) > knownWorks {
+		if err := utils.SaveWorks(works); err != nil {
+			return fmt.Errorf("error saving works: %w", err)
+		}
 	}
 
-	fmt.Printf("✅ Review for Chapter %d updated successfully!\n", reviewToEdit.Chapter)
-	return nil
-}
-
-func parseUint8(s string) (uint8, error) {
-	val, err := strconv.ParseUint(s, 10, 8)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse to number: %w", err)
+	if err := utils.SaveReviews(reviews); err != nil {
+		return fmt.Errorf("error saving reviews: %v", err)
 	}
-	return uint8(val), nil
-}
 
-func parseUint32(s string) (uint32, error) {
-	val, err := strconv.ParseUint(s, 10, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse to number: %w", err)
-	}
-	return uint32(val), nil
+	fmt.Printf("✅ Review for %s, %s updated successfully!\n", utils.WorkLabel(books, works, work), utils.FormatChapter(*reviewToEdit))
+	return nil
 }
@@ -0,0 +1,50 @@
+// Package list
+package list
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"utilodactyl/query"
+	"utilodactyl/utils"
+)
+
+// ListReviews prints the chapter reviews matching expr, one per line. An empty
+// expr lists every review.
+func ListReviews(expr string) error {
+	reviews, err := utils.LoadReviews()
+	if err != nil {
+		return fmt.Errorf("failed to load reviews: %w", err)
+	}
+
+	reviews, err = query.Filter(reviews, expr)
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	if len(reviews) == 0 {
+		fmt.Println("No matching reviews.")
+		return nil
+	}
+
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return fmt.Errorf("failed to load books: %w", err)
+	}
+	works, err := utils.LoadWorks()
+	if err != nil {
+		return fmt.Errorf("failed to load works: %w", err)
+	}
+	scale, err := utils.ReviewScale()
+	if err != nil {
+		return fmt.Errorf("failed to load review rating scale: %w", err)
+	}
+	reviews = utils.GroupReviewsByWork(reviews, books, works)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tWORK\tCHAPTER\tRATING\tDESCRIPTION")
+	for _, r := range reviews {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", r.ID, utils.WorkLabel(books, works, utils.ReviewWork(r)), utils.FormatChapter(r), scale.Format(r.Rating), r.Description)
+	}
+	return w.Flush()
+}
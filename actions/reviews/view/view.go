@@ -3,26 +3,139 @@ package view
 
 import (
 	"fmt"
+	"strings"
+	"time"
+	"utilodactyl/actions/reviews/edit"
+	"utilodactyl/models"
+	"utilodactyl/query"
+	"utilodactyl/ui/browser"
 	"utilodactyl/utils"
 )
 
 func ViewReviews() error {
-	reviews, err := utils.LoadReviews()
-	if err != nil {
-		return fmt.Errorf("failed to load reviews: %w", err)
+	for {
+		reviews, err := utils.LoadReviews()
+		if err != nil {
+			return fmt.Errorf("failed to load reviews: %w", err)
+		}
+
+		if len(reviews) == 0 {
+			fmt.Println("No reviews found")
+			return nil
+		}
+
+		books, err := utils.LoadBooks()
+		if err != nil {
+			return fmt.Errorf("failed to load books: %w", err)
+		}
+		works, err := utils.LoadWorks()
+		if err != nil {
+			return fmt.Errorf("failed to load works: %w", err)
+		}
+		arcs, err := utils.LoadArcs()
+		if err != nil {
+			return fmt.Errorf("failed to load arcs: %w", err)
+		}
+		scale, err := utils.ReviewScale()
+		if err != nil {
+			return fmt.Errorf("failed to load review rating scale: %w", err)
+		}
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		excluded := utils.ExcludedBookIDs(books, cfg.Books.MaxWarning, time.Now())
+		hidden := utils.Unpublished(cfg.Reviews.PrivateFields, nil)
+		reviews = utils.GroupReviewsByWork(reviews, books, works)
+		workOf := func(i int) string { return utils.WorkLabel(books, works, utils.ReviewWork(reviews[i])) }
+		arcOf := func(i int) string {
+			a, _ := utils.FindArc(arcs, reviews[i])
+			return a.Name
+		}
+
+		picked, err := browser.Run(browser.Config{
+			Title: "Chapter Reviews",
+			Len:   len(reviews),
+			Columns: []browser.Column{
+				{
+					Title: "Work", Width: 24, Value: workOf,
+					// The reviews are already grouped by work, in chapter order.
+					Less: func(a, b int) bool { return a < b },
+				},
+				{
+					Title: "Chapter", Width: 16,
+					Value: func(i int) string { return utils.FormatChapter(reviews[i]) },
+					Less:  func(a, b int) bool { return utils.CompareChapters(reviews[a], reviews[b]) < 0 },
+				},
+				{
+					Title: "Arc", Width: 18, Value: arcOf,
+					// Arcs run in chapter order, like the reviews.
+					Less: func(a, b int) bool { return a < b },
+				},
+				{
+					Title: "Rating", Width: 7,
+					Value: func(i int) string { return scale.Format(reviews[i].Rating) },
+					Less:  func(a, b int) bool { return reviews[a].Rating < reviews[b].Rating },
+				},
+				{Title: "Description", Width: 40, Value: func(i int) string { return reviews[i].Description }},
+			},
+			Detail: func(i int) string {
+				return reviewDetail(workOf(i), scale, excluded[reviews[i].BookID], hidden, arcs, reviews, reviews[i])
+			},
+			Filter: func(expr string) (func(i int) bool, error) {
+				match, err := query.Compile[models.Review](expr)
+				if err != nil {
+					return nil, err
+				}
+				return func(i int) bool { return match(&reviews[i]) }, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if picked < 0 {
+			return nil
+		}
+
+		if err := edit.EditReviewByID(reviews[picked].ID); err != nil {
+			fmt.Printf("Error editing review: %v\n", err)
+		}
 	}
+}
 
-	if len(reviews) == 0 {
-		fmt.Println("No reviews found")
-	} else {
-		for _, review := range reviews {
-			fmt.Printf("\nChapter: %d\n", review.Chapter)
-			fmt.Printf("Description: %s\n", review.Description)
-			fmt.Printf("Rating: %d/5\n", review.Rating)
-			fmt.Printf("Thoughts: %s\n", review.Thoughts)
+// reviewDetail describes a review, with a summary of the arc it falls in.
+// Reviews of excluded books are not published at all; otherwise the hidden
+// fields are marked with a lock.
+func reviewDetail(work string, scale utils.Scale, excluded bool, hidden map[string]bool, arcs []models.Arc, reviews []models.Review, review models.Review) string {
+	lock := func(field string) string {
+		if hidden[field] && !excluded {
+			return " 🔒"
 		}
+		return ""
 	}
 
-	return nil
+	var b strings.Builder
+	if excluded {
+		b.WriteString("🔒 Not published: the book is private, a draft, scheduled for later or over the content warning limit\n")
+	} else if len(hidden) > 0 {
+		b.WriteString("🔒 marks fields that are not published\n")
+	}
+	if review.Draft {
+		b.WriteString("Draft: not published until the draft flag is cleared\n")
+	}
+	if review.PublishAt != nil && review.PublishAt.After(time.Now()) {
+		fmt.Fprintf(&b, "Scheduled: published from %s\n", utils.FormatPublishAt(review.PublishAt))
+	}
+	fmt.Fprintf(&b, "Work: %s\n", work)
+	fmt.Fprintf(&b, "Chapter: %s\n", utils.FormatChapter(review))
+	if a, ok := utils.FindArc(arcs, review); ok {
+		fmt.Fprintf(&b, "Arc: %s (%s)\n", a.Name, utils.ArcRange(a))
+		if stats, ok := utils.ComputeArcStats(a, reviews); ok {
+			fmt.Fprintf(&b, "  %s\n", utils.FormatArcStats(stats, scale))
+		}
+	}
+	fmt.Fprintf(&b, "Description: %s%s\n", review.Description, lock("description"))
+	fmt.Fprintf(&b, "Rating: %s%s\n", scale.Format(review.Rating), lock("rating"))
+	fmt.Fprintf(&b, "\nThoughts%s: %s\n", lock("thoughts"), review.Thoughts)
+	return b.String()
 }
-
@@ -0,0 +1,38 @@
+// Package schedule publishes entries scheduled for later once they come due.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"utilodactyl/utils"
+)
+
+// PublishDue re-publishes the collections whose scheduled entries have come
+// due since they were last published. It does nothing otherwise, so cron can
+// run it as often as it likes.
+func PublishDue() error {
+	due, err := utils.DueCollections(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to check for scheduled entries: %w", err)
+	}
+
+	if len(due) == 0 {
+		fmt.Println("Nothing is due.")
+		return nil
+	}
+
+	var errs []error
+	for _, fileName := range due {
+		fmt.Printf("Publishing %s...\n", fileName)
+		if err := utils.UploadOrQueue(fileName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Published %d collection(s) with entries that came due.\n", len(due))
+	return nil
+}
@@ -0,0 +1,455 @@
+// Package tags implements the tag and genre manager shared by the
+// collections: usage counts, renaming, merging, splitting and deleting tags
+// across every entry, with a preview before anything is saved.
+package tags
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"utilodactyl/models"
+	"utilodactyl/ui/forms"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// Field is a tag-like list on the entries of a collection, such as genres.
+type Field[T any] struct {
+	Name  string // Plural, lowercase name, e.g. "genres".
+	Get   func(entry *T) *[]string
+	Terms func(vocab *models.Vocabulary) *[]models.VocabTerm // The field's vocabulary terms, if any.
+}
+
+// Collection adapts a collection to the manager.
+type Collection[T any] struct {
+	Noun       string // Singular, lowercase name of an entry, e.g. "book".
+	Load       func() ([]T, error)
+	Save       func([]T) error
+	Label      func(entry T) string
+	Fields     []Field[T]
+	Vocabulary string // The collection's vocabulary file, if it has one.
+}
+
+type managerAction int
+
+const (
+	showCounts managerAction = iota
+	renameTags
+	splitTag
+	deleteTags
+	reviewDuplicates
+	doneManaging
+)
+
+// Manage runs the manager for one of the collection's fields, asking which one
+// when there are several.
+func Manage[T any](c Collection[T]) error {
+	field := c.Fields[0]
+	if len(c.Fields) > 1 {
+		options := make([]huh.Option[int], len(c.Fields))
+		for i, f := range c.Fields {
+			options[i] = huh.NewOption(capitalize(f.Name), i)
+		}
+		var picked int
+		err := forms.Run(huh.NewSelect[int]().
+			Title("What would you like to manage?").
+			Options(options...).
+			Value(&picked))
+		if err != nil {
+			return err
+		}
+		field = c.Fields[picked]
+	}
+
+	for {
+		entries, err := c.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load %ss: %w", c.Noun, err)
+		}
+		vocab, err := loadVocabulary(c)
+		if err != nil {
+			return err
+		}
+		counts := countField(entries, field, vocab)
+		if len(counts) == 0 {
+			fmt.Printf("No %s %s to manage.\n", c.Noun, field.Name)
+			return nil
+		}
+		dups := utils.FindNearDuplicates(tagNames(counts))
+
+		action := showCounts
+		err = forms.Run(huh.NewSelect[managerAction]().
+			Title(fmt.Sprintf("Manage %s %s", c.Noun, field.Name)).
+			Description(fmt.Sprintf("%d %s, %d possible duplicates.", len(counts), field.Name, len(dups))).
+			Options(
+				huh.NewOption("Show usage counts", showCounts),
+				huh.NewOption("Rename or merge", renameTags),
+				huh.NewOption("Split one into several", splitTag),
+				huh.NewOption("Delete", deleteTags),
+				huh.NewOption(fmt.Sprintf("Review possible duplicates (%d)", len(dups)), reviewDuplicates),
+				huh.NewOption("Done", doneManaging),
+			).
+			Value(&action))
+		if err != nil {
+			return err
+		}
+
+		var from, to []string
+		switch action {
+		case doneManaging:
+			return nil
+		case showCounts:
+			printCounts(counts, dups)
+			continue
+		case renameTags:
+			from, to, err = askRename(field.Name, counts)
+		case splitTag:
+			from, to, err = askSplit(field.Name, counts)
+		case deleteTags:
+			from, err = askDelete(field.Name, counts)
+		case reviewDuplicates:
+			from, to, err = askDuplicate(field.Name, counts, dups)
+		}
+		if err != nil {
+			return err
+		}
+		if len(from) == 0 {
+			continue
+		}
+
+		if err := apply(c, field, entries, vocab, from, to); err != nil {
+			return err
+		}
+	}
+}
+
+// apply previews replacing from with to across all entries and the
+// vocabulary, and saves the result once confirmed.
+func apply[T any](c Collection[T], field Field[T], entries []T, vocab models.Vocabulary, from, to []string) error {
+	updated := slices.Clone(entries)
+
+	var preview strings.Builder
+	changed := 0
+	for i := range updated {
+		list := field.Get(&updated[i])
+		replaced, ok := utils.ReplaceTags(*list, from, to)
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&preview, "  %s: %s → %s\n", c.Label(updated[i]), formatList(*list), formatList(replaced))
+		*list = replaced
+		changed++
+	}
+
+	vocabChanged := false
+	if field.Terms != nil && len(*field.Terms(&vocab)) > 0 {
+		terms := field.Terms(&vocab)
+		renamed := utils.RenameTerms(*terms, from, to)
+		if vocabChanged = !reflect.DeepEqual(renamed, *terms); vocabChanged {
+			fmt.Fprintf(&preview, "  %s will be updated.\n", c.Vocabulary)
+			*terms = renamed
+		}
+	}
+
+	if changed == 0 && !vocabChanged {
+		fmt.Println("Nothing to change.")
+		return nil
+	}
+
+	fmt.Printf("\n%s\n%s\n", describeChange(from, to), preview.String())
+
+	var confirm bool
+	err := forms.Run(huh.NewConfirm().
+		Title(fmt.Sprintf("Apply to %d %s(s)?", changed, c.Noun)).
+		Value(&confirm))
+	if err != nil {
+		return err
+	}
+	if !confirm {
+		fmt.Println("No changes saved.")
+		return nil
+	}
+
+	if changed > 0 {
+		if err := c.Save(updated); err != nil {
+			return fmt.Errorf("failed to save %ss: %w", c.Noun, err)
+		}
+	}
+	if vocabChanged {
+		if err := utils.SaveVocabulary(c.Vocabulary, vocab); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("✅ Updated %d %s(s).\n", changed, c.Noun)
+	return nil
+}
+
+func askRename(noun string, counts []utils.TagCount) ([]string, []string, error) {
+	var from []string
+	var target string
+	err := forms.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title(fmt.Sprintf("Which %s? Pick several to merge them.", noun)).
+				Options(countOptions(counts)...).
+				Value(&from).
+				Filterable(true).
+				Height(12).
+				Validate(func(s []string) error {
+					if len(s) == 0 {
+						return fmt.Errorf("pick at least one")
+					}
+					return nil
+				}),
+			huh.NewInput().
+				Title("New name:").
+				Description("Use an existing name to merge into it.").
+				Suggestions(tagNames(counts)).
+				Value(&target).
+				Validate(nonEmpty),
+		),
+	).Run()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Keep the typed spelling when only the case of a tag is being changed.
+	to := strings.TrimSpace(target)
+	if !slices.ContainsFunc(from, func(f string) bool { return strings.EqualFold(f, to) }) {
+		to = canonicalName(counts, to)
+	}
+	return from, []string{to}, nil
+}
+
+func askSplit(noun string, counts []utils.TagCount) ([]string, []string, error) {
+	var from, targets string
+	err := forms.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(fmt.Sprintf("Which of the %s should be split?", noun)).
+				Options(countOptions(counts)...).
+				Value(&from).
+				Height(12),
+			huh.NewInput().
+				Title("Split into:").
+				Description("Comma-separated.").
+				Value(&targets).
+				Validate(func(s string) error {
+					if len(utils.SplitList(s)) < 2 {
+						return fmt.Errorf("enter at least two names")
+					}
+					return nil
+				}),
+		),
+	).Run()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to := utils.SplitList(targets)
+	for i, t := range to {
+		to[i] = canonicalName(counts, t)
+	}
+	return []string{from}, to, nil
+}
+
+// askDelete offers the least used tags first, since those are usually the
+// ones worth cleaning up. Unused tags go straight to the preview; tags still
+// on entries need confirming first, naming how many entries lose each.
+func askDelete(noun string, counts []utils.TagCount) ([]string, error) {
+	byUse := slices.Clone(counts)
+	slices.SortStableFunc(byUse, func(a, b utils.TagCount) int { return a.Count - b.Count })
+
+	var from []string
+	err := forms.Run(huh.NewMultiSelect[string]().
+		Title(fmt.Sprintf("Delete which %s from every entry?", noun)).
+		Description("Least used first, with the number of entries using each.").
+		Options(countOptions(byUse)...).
+		Value(&from).
+		Filterable(true).
+		Height(12))
+	if err != nil {
+		return nil, err
+	}
+
+	var inUse []string
+	for _, name := range from {
+		if n := countOf(counts, name); n > 0 {
+			inUse = append(inUse, fmt.Sprintf("%s (%d)", name, n))
+		}
+	}
+	if len(inUse) == 0 {
+		return from, nil
+	}
+
+	var confirm bool
+	err = forms.Run(huh.NewConfirm().
+		Title(fmt.Sprintf("%s still in use: %s. Delete anyway?", capitalize(noun), strings.Join(inUse, ", "))).
+		Description("The number is how many entries lose each one.").
+		Value(&confirm))
+	if err != nil {
+		return nil, err
+	}
+	if !confirm {
+		fmt.Println("Nothing deleted.")
+		return nil, nil
+	}
+	return from, nil
+}
+
+func askDuplicate(noun string, counts []utils.TagCount, dups []utils.NearDuplicate) ([]string, []string, error) {
+	if len(dups) == 0 {
+		fmt.Printf("No %s look like duplicates.\n", noun)
+		return nil, nil, nil
+	}
+
+	options := make([]huh.Option[int], len(dups))
+	for i, d := range dups {
+		options[i] = huh.NewOption(fmt.Sprintf("%s (%d) ≈ %s (%d)", d.A, countOf(counts, d.A), d.B, countOf(counts, d.B)), i)
+	}
+	var picked int
+	err := forms.Run(huh.NewSelect[int]().
+		Title("Which pair should be merged?").
+		Options(options...).
+		Value(&picked).
+		Height(12))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d := dups[picked]
+	keep := d.A
+	if countOf(counts, d.B) > countOf(counts, d.A) {
+		keep = d.B
+	}
+	err = forms.Run(huh.NewSelect[string]().
+		Title("Keep which spelling?").
+		Options(
+			huh.NewOption(d.A, d.A),
+			huh.NewOption(d.B, d.B),
+		).
+		Value(&keep))
+	if err != nil {
+		return nil, nil, err
+	}
+	return []string{d.A, d.B}, []string{keep}, nil
+}
+
+func loadVocabulary[T any](c Collection[T]) (models.Vocabulary, error) {
+	if c.Vocabulary == "" {
+		return models.Vocabulary{}, nil
+	}
+	return utils.LoadVocabulary(c.Vocabulary)
+}
+
+// countField counts the uses of each tag, including vocabulary terms no entry
+// uses yet, so that they can be cleaned up too.
+func countField[T any](entries []T, field Field[T], vocab models.Vocabulary) []utils.TagCount {
+	lists := make([][]string, len(entries))
+	for i := range entries {
+		lists[i] = *field.Get(&entries[i])
+	}
+	counts := utils.CountTags(lists)
+	if field.Terms == nil {
+		return counts
+	}
+
+	for _, name := range utils.TermNames(*field.Terms(&vocab)) {
+		if countOf(counts, name) == 0 {
+			counts = append(counts, utils.TagCount{Name: name})
+		}
+	}
+	slices.SortStableFunc(counts, func(a, b utils.TagCount) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return counts
+}
+
+func printCounts(counts []utils.TagCount, dups []utils.NearDuplicate) {
+	similar := make(map[string][]string)
+	for _, d := range dups {
+		similar[d.A] = append(similar[d.A], d.B)
+		similar[d.B] = append(similar[d.B], d.A)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tUSES\t")
+	for _, c := range counts {
+		note := ""
+		if s := similar[c.Name]; len(s) > 0 {
+			note = "⚠ similar to " + strings.Join(s, ", ")
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\n", c.Name, c.Count, note)
+	}
+	w.Flush()
+}
+
+func countOptions(counts []utils.TagCount) []huh.Option[string] {
+	options := make([]huh.Option[string], len(counts))
+	for i, c := range counts {
+		options[i] = huh.NewOption(fmt.Sprintf("%s (%d)", c.Name, c.Count), c.Name)
+	}
+	return options
+}
+
+func tagNames(counts []utils.TagCount) []string {
+	names := make([]string, len(counts))
+	for i, c := range counts {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func countOf(counts []utils.TagCount, name string) int {
+	for _, c := range counts {
+		if c.Name == name {
+			return c.Count
+		}
+	}
+	return 0
+}
+
+// canonicalName returns the spelling already in use for name, ignoring case.
+func canonicalName(counts []utils.TagCount, name string) string {
+	name = strings.TrimSpace(name)
+	for _, c := range counts {
+		if strings.EqualFold(c.Name, name) {
+			return c.Name
+		}
+	}
+	return name
+}
+
+func describeChange(from, to []string) string {
+	switch {
+	case len(to) == 0:
+		return fmt.Sprintf("Deleting %s:", formatList(from))
+	case len(from) > 1:
+		return fmt.Sprintf("Merging %s into %q:", formatList(from), to[0])
+	case len(to) > 1:
+		return fmt.Sprintf("Splitting %q into %s:", from[0], formatList(to))
+	default:
+		return fmt.Sprintf("Renaming %q to %q:", from[0], to[0])
+	}
+}
+
+func formatList(list []string) string {
+	return "[" + strings.Join(list, ", ") + "]"
+}
+
+func nonEmpty(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	return nil
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,290 @@
+// Package watch
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+	bookupdate "utilodactyl/actions/books/update"
+	gameupdate "utilodactyl/actions/games/update"
+	projectupdate "utilodactyl/actions/projects/update"
+	reviewupdate "utilodactyl/actions/reviews/update"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const logFile = "watch.log"
+
+// collection ties a watched JSON file to its validation and update action.
+// Inputs are the other files its published form is built from, so a change to
+// one of them publishes the collection again.
+type collection struct {
+	fileName   string
+	keyField   string
+	labelField string
+	inputs     []string
+	validate   func(data []byte) error
+	update     func() error
+}
+
+var collections = []collection{
+	{
+		fileName: "books.json", keyField: "id", labelField: "title",
+		inputs:   []string{utils.ConfigFile, utils.BookVocabularyFile, utils.SeriesFile, utils.PeopleFile},
+		validate: validateAs(utils.ValidateBooks),
+		update:   bookupdate.UpdateBooks,
+	},
+	{
+		fileName: "games.json", keyField: "id", labelField: "title",
+		inputs:   []string{utils.ConfigFile, utils.GameVocabularyFile},
+		validate: validateAs(utils.ValidateGames),
+		update:   gameupdate.UpdateGames,
+	},
+	{
+		fileName: "projects.json", keyField: "id", labelField: "name",
+		inputs:   []string{utils.ConfigFile},
+		validate: validateAs(utils.ValidateProjects),
+		update:   projectupdate.UpdateProjects,
+	},
+	{
+		// Publishing the reviews publishes the arcs too, so the arcs need no
+		// inputs of their own.
+		fileName: "reviews.json", keyField: "id", labelField: "description",
+		inputs:   []string{utils.ConfigFile, utils.WorksFile},
+		validate: validateAs(utils.ValidateReviews),
+		update:   reviewupdate.UpdateReviews,
+	},
+	{
+		fileName: utils.ArcsFile, keyField: "id", labelField: "name",
+		validate: validateAs(validateArcs),
+		update:   reviewupdate.UpdateReviews,
+	},
+}
+
+// validateArcs checks story arcs against the works they belong to.
+func validateArcs(arcs []models.Arc) error {
+	books, err := utils.LoadBooks()
+	if err != nil {
+		return err
+	}
+	works, err := utils.LoadWorks()
+	if err != nil {
+		return err
+	}
+	return utils.ValidateArcs(arcs, books, works)
+}
+
+// validateAs decodes a collection file strictly, so misspelled fields are
+// reported instead of silently dropped, and then runs its validator.
+func validateAs[T any](check func([]T) error) func([]byte) error {
+	return func(data []byte) error {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+
+		var items []T
+		if err := dec.Decode(&items); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		return check(items)
+	}
+}
+
+// Watch monitors the data directory and publishes each collection file after
+// it has been saved and has stopped changing for the debounce interval.
+// Progress is shown on a status line and every event is appended to watch.log.
+func Watch(debounce time.Duration) error {
+	f, err := os.OpenFile(logFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", logFile, err)
+	}
+	defer f.Close()
+	logger := log.New(f, "", log.LstdFlags)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Watch the directory rather than the files, since most editors save by
+	// writing a new file and renaming it over the old one.
+	if err := watcher.Add("."); err != nil {
+		return fmt.Errorf("failed to watch data directory: %w", err)
+	}
+
+	byName := make(map[string]*collection, len(collections))
+	dependents := make(map[string][]string)
+	published := make(map[string][]byte, len(collections))
+	for i := range collections {
+		c := &collections[i]
+		byName[c.fileName] = c
+		for _, input := range c.inputs {
+			dependents[input] = append(dependents[input], c.fileName)
+		}
+		published[c.fileName], _ = os.ReadFile(c.fileName)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	status := &statusLine{}
+	status.set("👀 Watching for changes (Ctrl+C to stop)")
+	logger.Println("watch started")
+
+	// Each change to a collection starts its debounce over. The timer it
+	// replaces may already have fired, so every firing carries the
+	// generation of its timer and only the latest generation is acted on.
+	type firing struct {
+		name       string
+		generation int
+	}
+	due := make(chan firing)
+	timers := make(map[string]*time.Timer)
+	generations := make(map[string]int)
+	// rebuilt holds the collections waiting to publish because an input
+	// changed, which publish even when their own file has not.
+	rebuilt := make(map[string]bool)
+
+	for {
+		select {
+		case <-ctx.Done():
+			status.clear()
+			logger.Println("watch stopped")
+			fmt.Println("Stopped watching.")
+			return nil
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Printf("watcher error: %v", err)
+			status.set(fmt.Sprintf("⚠️  Watcher error: %v (see %s)", err, logFile))
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			changed := filepath.Base(event.Name)
+			if !event.Has(fsnotify.Write | fsnotify.Create) {
+				continue
+			}
+			var targets []string
+			if byName[changed] != nil {
+				targets = append(targets, changed)
+			}
+			for _, name := range dependents[changed] {
+				rebuilt[name] = true
+				targets = append(targets, name)
+			}
+			if len(targets) == 0 {
+				continue
+			}
+			for _, name := range targets {
+				if t, ok := timers[name]; ok {
+					t.Stop()
+				}
+				generations[name]++
+				f := firing{name: name, generation: generations[name]}
+				timers[name] = time.AfterFunc(debounce, func() {
+					select {
+					case due <- f:
+					case <-ctx.Done():
+					}
+				})
+			}
+			status.set(fmt.Sprintf("✏️  %s changed, waiting for edits to settle...", changed))
+
+		case f := <-due:
+			if f.generation != generations[f.name] {
+				// Superseded by a later change; its own timer is pending.
+				continue
+			}
+			name := f.name
+			delete(timers, name)
+			force := rebuilt[name]
+			delete(rebuilt, name)
+			c := byName[name]
+			data, err := os.ReadFile(c.fileName)
+			if os.IsNotExist(err) && force {
+				// An input changed for a collection that is not kept.
+				continue
+			}
+			if err != nil {
+				logger.Printf("%s: read failed: %v", name, err)
+				status.set(fmt.Sprintf("❌ %s: could not be read (see %s)", name, logFile))
+				continue
+			}
+			if bytes.Equal(data, published[name]) && !force {
+				status.set(fmt.Sprintf("👀 %s unchanged, watching", name))
+				continue
+			}
+			if err := publish(c, published[name], data, status, logger); err != nil {
+				continue
+			}
+			published[name] = data
+		}
+	}
+}
+
+// publish validates, diffs and uploads one changed collection file.
+func publish(c *collection, before, after []byte, status *statusLine, logger *log.Logger) error {
+	if err := c.validate(after); err != nil {
+		logger.Printf("%s: validation failed: %v", c.fileName, err)
+		status.set(fmt.Sprintf("❌ %s is invalid, not publishing (see %s)", c.fileName, logFile))
+		return err
+	}
+
+	changes, err := utils.DiffJSON(before, after, c.keyField, c.labelField)
+	if err != nil {
+		logger.Printf("%s: diff failed: %v", c.fileName, err)
+	}
+
+	status.clear()
+	fmt.Printf("\n%s: %d entries changed\n", c.fileName, len(changes))
+	for _, change := range changes {
+		fmt.Println(change)
+		if models.Cli.Verbose {
+			logger.Printf("%s: %s", c.fileName, strings.ReplaceAll(change.String(), "\n", ";"))
+		}
+	}
+
+	status.set(fmt.Sprintf("⬆️  Publishing %s...", c.fileName))
+	if err := c.update(); err != nil {
+		logger.Printf("%s: publish failed: %v", c.fileName, err)
+		status.set(fmt.Sprintf("❌ Publishing %s failed (see %s)", c.fileName, logFile))
+		return err
+	}
+
+	logger.Printf("%s: published %d changes", c.fileName, len(changes))
+	status.set(fmt.Sprintf("✅ Published %s at %s, watching", c.fileName, time.Now().Format("15:04:05")))
+	return nil
+}
+
+// statusLine keeps a single, continuously rewritten line at the bottom of the
+// terminal output.
+type statusLine struct {
+	width int
+}
+
+func (s *statusLine) set(msg string) {
+	s.clear()
+	fmt.Print(msg)
+	s.width = len([]rune(msg))
+}
+
+func (s *statusLine) clear() {
+	if s.width == 0 {
+		return
+	}
+	fmt.Print("\r" + strings.Repeat(" ", s.width+2) + "\r")
+	s.width = 0
+}
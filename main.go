@@ -1,18 +1,72 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"utilodactyl/actions"
+	booklist "utilodactyl/actions/books/list"
+	gamelist "utilodactyl/actions/games/list"
+	"utilodactyl/actions/migrate"
+	"utilodactyl/actions/outbox"
+	projectlist "utilodactyl/actions/projects/list"
+	"utilodactyl/actions/rescale"
+	reviewlist "utilodactyl/actions/reviews/list"
+	"utilodactyl/actions/schedule"
+	"utilodactyl/actions/watch"
 	"utilodactyl/models"
 
 	"github.com/alexflint/go-arg"
+	"github.com/charmbracelet/huh"
+	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
 )
 
 func main() {
-	fmt.Print("\033[H\033[2J")
-	arg.MustParse(&models.Cli)
-	err := actions.App()
+	p := arg.MustParse(&models.Cli)
+
+	var err error
+	switch {
+	case models.Cli.Sync != nil:
+		err = outbox.Sync()
+	case models.Cli.Watch != nil:
+		err = watch.Watch(models.Cli.Watch.Debounce)
+	case models.Cli.PublishDue != nil:
+		err = schedule.PublishDue()
+	case models.Cli.Migrate != nil:
+		err = migrate.Migrate()
+	case models.Cli.Books != nil:
+		err = runCollectionCmd(p, "books", models.Cli.Books, booklist.ListBooks)
+	case models.Cli.Games != nil:
+		err = runCollectionCmd(p, "games", models.Cli.Games, gamelist.ListGames)
+	case models.Cli.Projects != nil:
+		err = runCollectionCmd(p, "projects", models.Cli.Projects, projectlist.ListProjects)
+	case models.Cli.Reviews != nil:
+		err = runCollectionCmd(p, "reviews", models.Cli.Reviews, reviewlist.ListReviews)
+	default:
+		if isatty.IsTerminal(os.Stdout.Fd()) {
+			termenv.NewOutput(os.Stdout).ClearScreen()
+		}
+		err = actions.App()
+	}
+
+	if errors.Is(err, huh.ErrUserAborted) {
+		return
+	}
 	if err != nil {
-		panic(err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runCollectionCmd runs a "<collection> <command>" invocation.
+func runCollectionCmd(p *arg.Parser, name string, cmd *models.CollectionCmd, list func(string) error) error {
+	if cmd.List != nil {
+		return list(cmd.List.Query)
+	}
+	if cmd.Rescale != nil {
+		return rescale.Rescale(name, cmd.Rescale.To)
 	}
+	p.FailSubcommand("missing command", name)
+	return nil
 }
@@ -1,51 +1,260 @@
 // Package models
 package models
 
+import "time"
+
 type Book struct {
 	ID          uint32     `json:"id"`          // Unique identifier for the book.
 	Title       string     `json:"title"`       // The title of the book.
-	Author      string     `json:"author"`      // The author of the book.
+	Author      string     `json:"author"`      // The author(s) of the book, kept in sync with Contributors.
 	Genres      []string   `json:"genres"`      // A list of genres the book belongs to.
-	Rating      uint16     `json:"rating"`      // User rating for the book (1-5).
+	Rating      Rating     `json:"rating"`      // User rating for the book, on the scale configured for books.
 	CoverImage  string     `json:"coverImage"`  // URL or path to the book's cover image.
 	Description string     `json:"description"` // A brief description of the book.
 	MyThoughts  string     `json:"myThoughts"`  // User's personal thoughts or review on the book.
 	Tags        []string   `json:"tags"`        // A list of tags associated with the book.
 	Links       []ItemLink `json:"links"`       // Relevant links for the book (e.g., purchase, review).
-	Status      string     `json:"status"`      // Current reading status (e.g., "Reading", "Finished").
-	Explicit    bool       `json:"explicit"`    // Indicates if the book contains explicit content.
+	Status      Status     `json:"status"`      // Current reading status (e.g., "Reading", "Finished").
+	Explicit    bool       `json:"explicit"`    // Indicates if the book contains explicit content; derived from its content warnings when it has any.
 	Color       string     `json:"color"`
+	Format      BookFormat `json:"format,omitempty"`   // How the book is being read.
+	Length      uint32     `json:"length,omitempty"`   // Number of pages, or minutes for audiobooks.
+	Position    uint32     `json:"position,omitempty"` // Current page, or minutes listened for audiobooks.
+
+	Contributors []Contributor `json:"contributors,omitempty"` // Authors, translators and illustrators, from the people registry.
+
+	ContentWarnings []ContentWarning `json:"contentWarnings,omitempty"` // Content readers may want warning about, from the book taxonomy.
+
+	Private       bool     `json:"private,omitempty"`       // Keep the whole book out of the public release.
+	PrivateFields []string `json:"privateFields,omitempty"` // Fields of this book kept out of the public release, e.g. "myThoughts".
+
+	Draft     bool       `json:"draft,omitempty"`     // Keep the book off the site until the flag is cleared.
+	PublishAt *time.Time `json:"publishAt,omitempty"` // Keep the book off the site until this time.
+
+	SeriesID       uint32  `json:"seriesId,omitempty"`       // The series the book belongs to, if any.
+	SeriesPosition float64 `json:"seriesPosition,omitempty"` // Place in the series' reading order; 2.5 fits a novella between 2 and 3.
+
+	AddedAt       *time.Time     `json:"addedAt,omitempty"`       // When the book was added; set by utils.SaveBooks.
+	UpdatedAt     *time.Time     `json:"updatedAt,omitempty"`     // When the book last changed; set by utils.SaveBooks.
+	FinishedAt    *time.Time     `json:"finishedAt,omitempty"`    // When the book last moved to a completing status.
+	StatusHistory []StatusChange `json:"statusHistory,omitempty"` // Every status the book has had, oldest first.
 }
 
+// Series is a named series of books. Its entries are the books pointing at it
+// through SeriesID, in the order of their SeriesPosition.
+type Series struct {
+	ID   uint32 `json:"id"`
+	Name string `json:"name"`
+}
+
+// BookFormat is the edition of a book being read, which decides whether its
+// length and position are in pages or minutes.
+type BookFormat string
+
+const (
+	FormatPhysical  BookFormat = "physical"
+	FormatEbook     BookFormat = "ebook"
+	FormatAudiobook BookFormat = "audiobook"
+)
+
 type Game struct {
 	ID          uint32     `json:"id"`
 	Title       string     `json:"title"`
 	Developer   string     `json:"developer"`
 	Genres      []string   `json:"genres"`
 	Tags        []string   `json:"tags"`
-	Rating      uint32     `json:"rating"`
-	Status      string     `json:"status"`
+	Rating      Rating     `json:"rating"`
+	Status      Status     `json:"status"`
 	Description string     `json:"description"`
 	MyThoughts  string     `json:"myThoughts"`
 	Links       []ItemLink `json:"links"`
 	Explicit    bool       `json:"explicit"`
 	CoverImage  string     `json:"coverImage"`
 	Percent     uint32     `json:"percent"`
+
+	ContentWarnings []ContentWarning `json:"contentWarnings,omitempty"`
+
+	Private       bool     `json:"private,omitempty"`
+	PrivateFields []string `json:"privateFields,omitempty"`
+
+	Draft     bool       `json:"draft,omitempty"`
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+
+	Sessions []PlaySession `json:"sessions,omitempty"`
+
+	AddedAt       *time.Time     `json:"addedAt,omitempty"`
+	UpdatedAt     *time.Time     `json:"updatedAt,omitempty"`
+	FinishedAt    *time.Time     `json:"finishedAt,omitempty"`
+	StatusHistory []StatusChange `json:"statusHistory,omitempty"`
+}
+
+// PlaySession is one sitting with a game. Percent is set when the session
+// moved the game's progression.
+type PlaySession struct {
+	Date    string  `json:"date"`
+	Minutes uint32  `json:"minutes"`
+	Note    string  `json:"note,omitempty"`
+	Percent *uint32 `json:"percent,omitempty"`
 }
 
 type Project struct {
-	Name           string   `json:"name"`
-	Description    string   `json:"description"`
-	Tags           []string `json:"tags"`
-	Source         string   `json:"source"`
-	InstallCommand string   `json:"installCommand"`
+	ID             uint32     `json:"id"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description"`
+	Tags           []string   `json:"tags"`
+	Source         string     `json:"source"`
+	InstallCommand string     `json:"installCommand"`
+	Links          []ItemLink `json:"links,omitempty"`
+
+	Private       bool     `json:"private,omitempty"`
+	PrivateFields []string `json:"privateFields,omitempty"`
+
+	Draft     bool       `json:"draft,omitempty"`
+	PublishAt *time.Time `json:"publishAt,omitempty"`
 }
 
+// Review is a review of one chapter of a work: either a book from the book
+// collection or a serial or webcomic from the works list. Reviews written
+// before reviews named their work have neither ID set.
+//
+// A chapter is numbered, possibly with decimals such as 10.5, or is a special
+// chapter named by Label, optionally numbered too ("Extra 2"). Volume is 0
+// for works not split into volumes.
 type Review struct {
-	Chapter     uint32 `json:"chapter"`
-	Description string `json:"description"`
-	Rating      uint8  `json:"rating"`
-	Thoughts    string `json:"thoughts"`
+	ID          uint32       `json:"id"`
+	BookID      uint32       `json:"bookId,omitempty"`
+	WorkID      uint32       `json:"workId,omitempty"`
+	Volume      uint32       `json:"volume,omitempty"`
+	Label       ChapterLabel `json:"label,omitempty"`
+	Chapter     float64      `json:"chapter"`
+	Description string       `json:"description"`
+	Rating      Rating       `json:"rating"`
+	Thoughts    string       `json:"thoughts"`
+
+	Draft     bool       `json:"draft,omitempty"`
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+}
+
+// ChapterLabel names a special chapter. Prologues come before the numbered
+// chapters of their volume; epilogues and extras come after them.
+type ChapterLabel string
+
+const (
+	LabelPrologue ChapterLabel = "Prologue"
+	LabelEpilogue ChapterLabel = "Epilogue"
+	LabelExtra    ChapterLabel = "Extra"
+)
+
+// Work is a serial or webcomic that chapter reviews can be written for,
+// without being part of the book collection.
+type Work struct {
+	ID    uint32   `json:"id"`
+	Title string   `json:"title"`
+	Kind  WorkKind `json:"kind"`
+	URL   string   `json:"url,omitempty"`
+}
+
+type WorkKind string
+
+const (
+	WorkSerial   WorkKind = "serial"
+	WorkWebcomic WorkKind = "webcomic"
+)
+
+// Arc is a named story arc of a work: the chapters from First to Last,
+// inclusive, in the order reviews sort in.
+type Arc struct {
+	ID     uint32       `json:"id"`
+	BookID uint32       `json:"bookId,omitempty"`
+	WorkID uint32       `json:"workId,omitempty"`
+	Name   string       `json:"name"`
+	First  ChapterPoint `json:"first"`
+	Last   ChapterPoint `json:"last"`
+}
+
+// ChapterPoint is a chapter of a work, numbered the way reviews number theirs.
+type ChapterPoint struct {
+	Volume  uint32       `json:"volume,omitempty"`
+	Label   ChapterLabel `json:"label,omitempty"`
+	Chapter float64      `json:"chapter"`
+}
+
+// BulkSession is a bulk review entry in progress: the reviews entered so far
+// and where to carry on, kept until they are saved to the reviews.
+type BulkSession struct {
+	BookID  uint32       `json:"bookId,omitempty"`
+	WorkID  uint32       `json:"workId,omitempty"`
+	Next    ChapterPoint `json:"next"`              // The chapter to ask about next.
+	Last    ChapterPoint `json:"last"`              // The last chapter of the range.
+	Compact bool         `json:"compact,omitempty"` // Whether to ask for ratings only.
+	Reviews []Review     `json:"reviews"`           // The reviews entered so far.
+}
+
+// Status is the progress of a book or game, e.g. "Reading". The statuses a
+// collection allows are configured with StatusConfig.
+type Status string
+
+// StatusConfig defines a status of a collection and how entries move out of it.
+type StatusConfig struct {
+	Name      Status   `json:"name"`
+	Next      []Status `json:"next,omitempty"` // Statuses an entry may move to from this one.
+	Complete  bool     `json:"complete"`       // Moving here stamps a finish date and sets games to 100%.
+	AskReason bool     `json:"askReason"`      // Moving here asks why, e.g. for "Dropped".
+}
+
+// StatusChange records when an entry moved to a status.
+type StatusChange struct {
+	Status Status    `json:"status"`
+	At     time.Time `json:"at"`
+	Note   string    `json:"note,omitempty"` // Why the status changed, when asked.
+}
+
+// Person is a person or studio in the people registry, shared by the
+// collections. Aliases are other spellings that resolve to the same person.
+type Person struct {
+	ID      uint32   `json:"id"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	Roles   []Role   `json:"roles,omitempty"` // The roles the person has been credited with.
+}
+
+// Role is what a person contributed to an entry.
+type Role string
+
+const (
+	RoleAuthor      Role = "author"
+	RoleTranslator  Role = "translator"
+	RoleIllustrator Role = "illustrator"
+	RoleDeveloper   Role = "developer"
+	RolePublisher   Role = "publisher"
+)
+
+// Contributor credits a person from the registry with a role on an entry.
+type Contributor struct {
+	PersonID uint32 `json:"personId"`
+	Role     Role   `json:"role"`
+}
+
+// ContentWarning flags content an entry has, such as violence, and how
+// strongly.
+type ContentWarning struct {
+	Category string   `json:"category"` // A category of the collection's content-warning taxonomy.
+	Severity Severity `json:"severity"`
+}
+
+// Severity is how strong a content warning is.
+type Severity string
+
+const (
+	SeverityMild     Severity = "mild"
+	SeverityModerate Severity = "moderate"
+	SeveritySevere   Severity = "severe"
+)
+
+// WarningCategory is a kind of content warning in a collection's taxonomy.
+type WarningCategory struct {
+	Name     string   `json:"name"`
+	Explicit Severity `json:"explicit,omitempty"` // From this severity on, the warning marks an entry explicit; never when empty.
 }
 
 type ItemLink struct {
@@ -53,6 +262,104 @@ type ItemLink struct {
 	URL   string `json:"url"`   // The URL of the link.
 }
 
+// Config holds settings for each collection, read from utilodactyl.json in the
+// data directory. Every setting is optional.
+type Config struct {
+	Books    CollectionConfig `json:"books"`
+	Games    CollectionConfig `json:"games"`
+	Reviews  CollectionConfig `json:"reviews"`
+	Projects CollectionConfig `json:"projects"` // Only privateFields applies to projects.
+
+	PrivateRelease *ReleaseConfig `json:"privateRelease,omitempty"` // Where the full collections are uploaded; they stay local when unset.
+}
+
+// ReleaseConfig names a GitHub release that collection files are uploaded to.
+type ReleaseConfig struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	Tag   string `json:"tag"`
+}
+
+type CollectionConfig struct {
+	ExportTimestamps bool           `json:"exportTimestamps"`      // Publish addedAt, updatedAt, finishedAt and the status history.
+	Statuses         []StatusConfig `json:"statuses"`              // Replaces the default statuses and workflow.
+	RatingScale      RatingScale    `json:"ratingScale,omitempty"` // The scale ratings are given on; 1–5 whole stars by default.
+
+	ContentWarnings []WarningCategory `json:"contentWarnings,omitempty"` // Replaces the default content-warning taxonomy.
+	MaxWarning      Severity          `json:"maxWarning,omitempty"`      // Leave entries with a stronger warning out of the published file.
+	PrivateFields   []string          `json:"privateFields,omitempty"`   // Fields of every entry kept out of the public release.
+}
+
+// Rating is a rating on the scale configured for its collection, e.g. 4.5 on
+// the half-star scale or 85 on the 100-point scale.
+type Rating float64
+
+// RatingScale names a rating scale.
+type RatingScale string
+
+const (
+	ScaleStars     RatingScale = "5"      // 1 to 5 whole stars.
+	ScaleHalfStars RatingScale = "5-half" // 0.5 to 5 stars in half steps.
+	ScaleTen       RatingScale = "10"     // 1 to 10 points.
+	ScaleHundred   RatingScale = "100"    // 1 to 100 points.
+)
+
+// Vocabulary is the controlled set of genres and tags for a collection, kept
+// next to it in a "<collection>.vocab.json" file.
+type Vocabulary struct {
+	ExpandParents bool        `json:"expandParents"` // Publish the parents of each genre or tag along with it.
+	Genres        []VocabTerm `json:"genres"`
+	Tags          []VocabTerm `json:"tags"`
+}
+
+// VocabTerm is a canonical genre or tag.
+type VocabTerm struct {
+	Name    string   `json:"name"`              // The canonical spelling.
+	Aliases []string `json:"aliases,omitempty"` // Other spellings that mean the same thing.
+	Parent  string   `json:"parent,omitempty"`  // A broader term, e.g. "sci-fi" for "cyberpunk".
+}
+
+// OutboxEntry is a collection upload that failed or was made while offline and
+// is waiting for the next sync.
+type OutboxEntry struct {
+	FileName  string    `json:"fileName"`  // The collection file to upload (e.g. "books.json").
+	QueuedAt  time.Time `json:"queuedAt"`  // When the upload was first queued.
+	UpdatedAt time.Time `json:"updatedAt"` // When the upload was last requested again.
+	Attempts  int       `json:"attempts"`  // How many upload attempts have failed.
+	LastError string    `json:"lastError"` // The error from the most recent failed attempt.
+}
+
+type SyncCmd struct{}
+
+type PublishDueCmd struct{}
+
+type MigrateCmd struct{}
+
+type WatchCmd struct {
+	Debounce time.Duration `arg:"--debounce" default:"750ms" help:"How long a file must stop changing before it is published"`
+}
+
+type ListCmd struct {
+	Query string `arg:"-q,--query" help:"Only list entries matching this query, e.g. 'status:Reading rating>=4'"`
+}
+
+type RescaleCmd struct {
+	To RatingScale `arg:"positional,required" help:"The scale to convert the ratings to: 5, 5-half, 10 or 100"`
+}
+
+type CollectionCmd struct {
+	List    *ListCmd    `arg:"subcommand:list" help:"List entries, optionally filtered by a query"`
+	Rescale *RescaleCmd `arg:"subcommand:rescale" help:"Convert the ratings to another scale, refusing if any would lose precision"`
+}
+
 var Cli struct {
-	Verbose bool `arg:"-v,--verbose" help:"Show advanced logs when updating data"`
+	Verbose    bool           `arg:"-v,--verbose" help:"Show advanced logs when updating data"`
+	Sync       *SyncCmd       `arg:"subcommand:sync" help:"Upload the collections queued while offline"`
+	Watch      *WatchCmd      `arg:"subcommand:watch" help:"Publish collection files automatically when they change"`
+	PublishDue *PublishDueCmd `arg:"subcommand:publish-due" help:"Publish the collections whose scheduled entries have come due; meant for cron"`
+	Migrate    *MigrateCmd    `arg:"subcommand:migrate" help:"Write the IDs older collection files lack back to them"`
+	Books      *CollectionCmd `arg:"subcommand:books" help:"Work with books.json"`
+	Games      *CollectionCmd `arg:"subcommand:games" help:"Work with games.json"`
+	Projects   *CollectionCmd `arg:"subcommand:projects" help:"Work with projects.json"`
+	Reviews    *CollectionCmd `arg:"subcommand:reviews" help:"Work with reviews.json"`
 }
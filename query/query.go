@@ -0,0 +1,437 @@
+// Package query implements a small search language for filtering collection
+// entries, e.g.
+//
+//	status:Reading rating>=4 genre:fantasy tag:"found family" explicit:false author~sanderson
+//
+// A query is a list of terms that must all match. A term is either
+// field<op>value or a bare word, which matches any text field. Fields are the
+// entry's JSON names (case-insensitive, singular or plural). Operators:
+//
+//	:   equals (case-insensitive); for lists, any element equals
+//	=   same as ":"
+//	!=  not equal; for lists, no element equals
+//	~   contains (case-insensitive); for lists, any element contains
+//	> >= < <=  numeric comparison, or alphabetical for text
+//
+// Prefixing a term with "-" negates it. Values containing spaces are quoted.
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query is a parsed query. The zero value matches everything.
+type Query struct {
+	terms []term
+}
+
+type term struct {
+	field  string // Empty for free-text terms.
+	op     string
+	value  string
+	negate bool
+}
+
+// operators are checked longest first so ">=" wins over ">".
+var operators = []string{">=", "<=", "!=", ":", "~", "=", ">", "<"}
+
+// Parse parses a query string.
+func Parse(s string) (Query, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return Query{}, err
+	}
+
+	var q Query
+	for _, tok := range tokens {
+		t := term{}
+		if strings.HasPrefix(tok, "-") && len(tok) > 1 {
+			t.negate = true
+			tok = tok[1:]
+		}
+
+		pos, op := findOperator(tok)
+		if pos <= 0 {
+			t.value = unquote(tok)
+			q.terms = append(q.terms, t)
+			continue
+		}
+
+		t.field = strings.ToLower(tok[:pos])
+		t.op = op
+		t.value = unquote(tok[pos+len(op):])
+		if t.value == "" {
+			return Query{}, fmt.Errorf("missing value after %q", tok[:pos+len(op)])
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q, nil
+}
+
+// Empty reports whether the query has no terms.
+func (q Query) Empty() bool {
+	return len(q.terms) == 0
+}
+
+// tokenize splits s on whitespace, keeping double-quoted sections together.
+func tokenize(s string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	inQuotes := false
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			cur.WriteRune(r)
+		case !inQuotes && (r == ' ' || r == '\t' || r == '\n'):
+			if cur.Len() > 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+	if cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens, nil
+}
+
+// findOperator returns the position and text of the first operator outside of
+// quotes, or -1 if the token is a bare word.
+func findOperator(tok string) (int, string) {
+	for i := 0; i < len(tok); i++ {
+		if tok[i] == '"' {
+			return -1, ""
+		}
+		for _, op := range operators {
+			if strings.HasPrefix(tok[i:], op) {
+				return i, op
+			}
+		}
+	}
+	return -1, ""
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// Check reports terms that name fields the entry type t does not have, or
+// give a value the field's type cannot hold.
+func (q Query) Check(t reflect.Type) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for _, term := range q.terms {
+		if term.field == "" {
+			continue
+		}
+		idx, ok := fieldIndex(t, term.field)
+		if !ok {
+			return fmt.Errorf("unknown field %q", term.field)
+		}
+		if err := checkValue(t.FieldByIndex(idx).Type, term); err != nil {
+			return fmt.Errorf("field %q: %w", term.field, err)
+		}
+	}
+	return nil
+}
+
+// checkValue reports values that cannot be compared with a field of type t,
+// which would otherwise match nothing.
+func checkValue(t reflect.Type, term term) error {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		if _, _, err := parseTime(term.value); err != nil {
+			return fmt.Errorf("%q is not a date (2006-01-02) or an RFC 3339 time", term.value)
+		}
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if term.op != ":" && term.op != "=" && term.op != "!=" {
+			return fmt.Errorf("%q does not apply to true/false values", term.op)
+		}
+		if _, err := parseBool(term.value); err != nil {
+			return fmt.Errorf("%q is not true or false", term.value)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if term.op == "~" {
+			return nil
+		}
+		if _, err := strconv.ParseFloat(term.value, 64); err != nil {
+			return fmt.Errorf("%q is not a number", term.value)
+		}
+	}
+	return nil
+}
+
+// Match reports whether the entry v (a struct or pointer to one) matches every
+// term of the query.
+func (q Query) Match(v any) bool {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+
+	for _, t := range q.terms {
+		if matchTerm(rv, t) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Compile parses expr and checks it against the entry type T, returning a
+// predicate for entries of that type.
+func Compile[T any](expr string) (func(*T) bool, error) {
+	q, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.Check(reflect.TypeOf((*T)(nil)).Elem()); err != nil {
+		return nil, err
+	}
+	return func(item *T) bool { return q.Match(item) }, nil
+}
+
+// Filter returns the items matching expr.
+func Filter[T any](items []T, expr string) ([]T, error) {
+	match, err := Compile[T](expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []T
+	for i := range items {
+		if match(&items[i]) {
+			matched = append(matched, items[i])
+		}
+	}
+	return matched, nil
+}
+
+// fieldIndex finds a struct field by its JSON name, accepting a singular form
+// for plural fields ("tag" for "tags").
+func fieldIndex(t reflect.Type, name string) ([]int, bool) {
+	for _, candidate := range []string{name, name + "s"} {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+			if jsonName == "" {
+				jsonName = f.Name
+			}
+			if strings.EqualFold(jsonName, candidate) {
+				return f.Index, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func matchTerm(rv reflect.Value, t term) bool {
+	if t.field == "" {
+		return matchFreeText(rv, strings.ToLower(t.value))
+	}
+
+	idx, ok := fieldIndex(rv.Type(), t.field)
+	if !ok {
+		return false
+	}
+	return matchValue(rv.FieldByIndex(idx), t.op, t.value)
+}
+
+// matchFreeText reports whether any text field of the entry contains needle.
+func matchFreeText(rv reflect.Value, needle string) bool {
+	for i := 0; i < rv.NumField(); i++ {
+		if !rv.Type().Field(i).IsExported() {
+			continue
+		}
+		f := rv.Field(i)
+		switch {
+		case f.Kind() == reflect.String:
+			if strings.Contains(strings.ToLower(f.String()), needle) {
+				return true
+			}
+		case f.Kind() == reflect.Slice && f.Type().Elem().Kind() == reflect.String:
+			for j := 0; j < f.Len(); j++ {
+				if strings.Contains(strings.ToLower(f.Index(j).String()), needle) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func matchValue(f reflect.Value, op, value string) bool {
+	for f.Kind() == reflect.Pointer {
+		if f.IsNil() {
+			return op == "!="
+		}
+		f = f.Elem()
+	}
+
+	if t, ok := f.Interface().(time.Time); ok {
+		return matchTime(t, op, value)
+	}
+
+	switch f.Kind() {
+	case reflect.Slice, reflect.Array:
+		if op == "!=" {
+			for i := 0; i < f.Len(); i++ {
+				if matchValue(f.Index(i), ":", value) {
+					return false
+				}
+			}
+			return true
+		}
+		for i := 0; i < f.Len(); i++ {
+			if matchValue(f.Index(i), op, value) {
+				return true
+			}
+		}
+		return false
+
+	case reflect.Bool:
+		want, err := parseBool(value)
+		if err != nil {
+			return false
+		}
+		switch op {
+		case ":", "=":
+			return f.Bool() == want
+		case "!=":
+			return f.Bool() != want
+		}
+		return false
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareNumber(float64(f.Int()), op, value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareNumber(float64(f.Uint()), op, value)
+	case reflect.Float32, reflect.Float64:
+		return compareNumber(f.Float(), op, value)
+
+	case reflect.Struct:
+		// Structs such as links match on any of their text fields.
+		return matchFreeText(f, strings.ToLower(value)) == (op != "!=")
+	}
+
+	return compareText(fmt.Sprint(f.Interface()), op, value)
+}
+
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "yes", "y":
+		return true, nil
+	case "no", "n":
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
+
+func compareNumber(n float64, op, value string) bool {
+	want, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return op == "!="
+	}
+	switch op {
+	case ":", "=":
+		return n == want
+	case "!=":
+		return n != want
+	case ">":
+		return n > want
+	case ">=":
+		return n >= want
+	case "<":
+		return n < want
+	case "<=":
+		return n <= want
+	case "~":
+		return strings.Contains(strconv.FormatFloat(n, 'f', -1, 64), value)
+	}
+	return false
+}
+
+func compareText(s, op, value string) bool {
+	s, value = strings.ToLower(s), strings.ToLower(value)
+	switch op {
+	case ":", "=":
+		return s == value
+	case "!=":
+		return s != value
+	case "~":
+		return strings.Contains(s, value)
+	case ">":
+		return s > value
+	case ">=":
+		return s >= value
+	case "<":
+		return s < value
+	case "<=":
+		return s <= value
+	}
+	return false
+}
+
+// matchTime compares a timestamp with a date (2006-01-02) or RFC 3339 value.
+// ":" and "=" match anywhere within the given day.
+func matchTime(t time.Time, op, value string) bool {
+	want, day, err := parseTime(value)
+	if err != nil {
+		return false
+	}
+	if t.IsZero() {
+		return op == "!="
+	}
+
+	if day {
+		t = time.Date(t.In(time.Local).Year(), t.In(time.Local).Month(), t.In(time.Local).Day(), 0, 0, 0, 0, time.Local)
+	}
+	switch op {
+	case ":", "=", "~":
+		return t.Equal(want)
+	case "!=":
+		return !t.Equal(want)
+	case ">":
+		return t.After(want)
+	case ">=":
+		return !t.Before(want)
+	case "<":
+		return t.Before(want)
+	case "<=":
+		return !t.After(want)
+	}
+	return false
+}
+
+// parseTime reads a date (2006-01-02), reporting day as true, or an RFC 3339
+// time.
+func parseTime(value string) (want time.Time, day bool, err error) {
+	if want, err = time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return want, true, nil
+	}
+	want, err = time.Parse(time.RFC3339, value)
+	return want, false, err
+}
@@ -0,0 +1,186 @@
+package query
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type link struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type entry struct {
+	Title      string     `json:"title"`
+	Author     string     `json:"author"`
+	Status     string     `json:"status"`
+	Rating     float64    `json:"rating"`
+	Chapters   uint32     `json:"chapters"`
+	Explicit   bool       `json:"explicit"`
+	Genres     []string   `json:"genres"`
+	Tags       []string   `json:"tags"`
+	Links      []link     `json:"links"`
+	AddedAt    time.Time  `json:"addedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+func testEntries() []entry {
+	finished := time.Date(2024, 3, 9, 20, 30, 0, 0, time.Local)
+	return []entry{
+		{
+			Title: "The Way of Kings", Author: "Brandon Sanderson", Status: "Reading", Rating: 4.5,
+			Chapters: 75, Genres: []string{"Fantasy"}, Tags: []string{"found family", "epic"},
+			Links:   []link{{Name: "Coppermind", URL: "https://coppermind.net"}},
+			AddedAt: time.Date(2024, 1, 2, 9, 0, 0, 0, time.Local),
+		},
+		{
+			Title: "Dune", Author: "Frank Herbert", Status: "Finished", Rating: 5,
+			Chapters: 48, Genres: []string{"Science Fiction"}, Tags: []string{"classic"},
+			AddedAt: time.Date(2023, 6, 1, 9, 0, 0, 0, time.Local), FinishedAt: &finished,
+		},
+		{
+			Title: "Night Watch", Author: "Terry Pratchett", Status: "Planning", Rating: 3,
+			Explicit: true, Genres: []string{"Fantasy", "Comedy"},
+			AddedAt: time.Date(2024, 3, 9, 23, 0, 0, 0, time.Local),
+		},
+	}
+}
+
+func titles(items []entry) string {
+	names := make([]string, len(items))
+	for i, e := range items {
+		names[i] = e.Title
+	}
+	return strings.Join(names, ", ")
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", "The Way of Kings, Dune, Night Watch"},
+
+		// Equality is case-insensitive, with ":" and "=" alike.
+		{"status:reading", "The Way of Kings"},
+		{"status=Finished", "Dune"},
+		{"status!=reading", "Dune, Night Watch"},
+
+		// Contains.
+		{"author~sanderson", "The Way of Kings"},
+		{"title~WATCH", "Night Watch"},
+
+		// Numeric comparisons.
+		{"rating>=4.5", "The Way of Kings, Dune"},
+		{"rating>4.5", "Dune"},
+		{"rating<4", "Night Watch"},
+		{"rating<=3", "Night Watch"},
+		{"rating:5", "Dune"},
+		{"chapters~7", "The Way of Kings"},
+
+		// Text compares alphabetically.
+		{"title<e", "Dune"},
+
+		// Booleans accept yes/no as well as true/false.
+		{"explicit:true", "Night Watch"},
+		{"explicit:no", "The Way of Kings, Dune"},
+		{"explicit!=y", "The Way of Kings, Dune"},
+
+		// Lists match on any element; "!=" on none. Plural fields can be
+		// named in the singular.
+		{"genre:fantasy", "The Way of Kings, Night Watch"},
+		{"genres:fantasy", "The Way of Kings, Night Watch"},
+		{"genre!=fantasy", "Dune"},
+		{"tag~fam", "The Way of Kings"},
+
+		// Structs match on any of their text fields.
+		{"link~coppermind", "The Way of Kings"},
+
+		// Dates match the whole day; unset times only match "!=".
+		{"addedAt:2024-03-09", "Night Watch"},
+		{"addedAt>=2024-01-01", "The Way of Kings, Night Watch"},
+		{"finishedAt<2024-03-10", "Dune"},
+		{"finishedAt!=2024-03-09", "The Way of Kings, Night Watch"},
+		{"addedAt<2024-01-01T00:00:00Z", "Dune"},
+
+		// Quoting keeps spaces in values and bare words.
+		{`tag:"found family"`, "The Way of Kings"},
+		{`genre:"science fiction"`, "Dune"},
+		{`"way of"`, "The Way of Kings"},
+
+		// Bare words match any text field.
+		{"herbert", "Dune"},
+		{"comedy", "Night Watch"},
+
+		// Negation, and terms that must all match.
+		{"-status:reading", "Dune, Night Watch"},
+		{"-genre:fantasy", "Dune"},
+		{"-herbert", "The Way of Kings, Night Watch"},
+		{"genre:fantasy rating>=4", "The Way of Kings"},
+		{"genre:fantasy -explicit:true", "The Way of Kings"},
+	}
+
+	for _, tt := range tests {
+		got, err := Filter(testEntries(), tt.expr)
+		if err != nil {
+			t.Errorf("Filter(%q): %v", tt.expr, err)
+			continue
+		}
+		if titles(got) != tt.want {
+			t.Errorf("Filter(%q) = [%s], want [%s]", tt.expr, titles(got), tt.want)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string // A fragment the error must contain.
+	}{
+		{`tag:"found family`, "unterminated quote"},
+		{"rating>=", "missing value"},
+		{"pages>100", `unknown field "pages"`},
+		{"rating>=four", `field "rating"`},
+		{"chapters:many", `field "chapters"`},
+		{"explicit:maybe", `field "explicit"`},
+		{"explicit>true", `field "explicit"`},
+		{"addedAt>yesterday", `field "addedat"`},
+		{"finishedAt:2024-13-01", `field "finishedat"`},
+	}
+
+	for _, tt := range tests {
+		_, err := Compile[entry](tt.expr)
+		if err == nil {
+			t.Errorf("Compile(%q) succeeded, want an error", tt.expr)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Compile(%q) error = %q, want it to mention %s", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	q, err := Parse(`-Status:Reading tag:"found family" dune`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []term{
+		{field: "status", op: ":", value: "Reading", negate: true},
+		{field: "tag", op: ":", value: "found family"},
+		{value: "dune"},
+	}
+	if len(q.terms) != len(want) {
+		t.Fatalf("Parse gave %d terms, want %d: %+v", len(q.terms), len(want), q.terms)
+	}
+	for i := range want {
+		if q.terms[i] != want[i] {
+			t.Errorf("term %d = %+v, want %+v", i, q.terms[i], want[i])
+		}
+	}
+
+	if q, err := Parse("   "); err != nil || !q.Empty() {
+		t.Errorf("Parse of blank text = %+v, %v; want an empty query", q, err)
+	}
+}
@@ -0,0 +1,312 @@
+// Package browser implements a full-screen, scrollable table for browsing a
+// collection, with a detail pane, incremental search and column sorting.
+package browser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Column describes one table column. Value renders the cell for entry i.
+// Less orders two entries when sorting by this column; when it is nil the
+// rendered values are compared case-insensitively.
+type Column struct {
+	Title string
+	Width int
+	Value func(i int) string
+	Less  func(a, b int) bool
+}
+
+// Config describes the collection being browsed.
+type Config struct {
+	Title   string
+	Len     int
+	Columns []Column
+	Detail  func(i int) string // Text shown in the detail pane for entry i.
+
+	// Filter turns the search text into a predicate over entries. When it is
+	// nil, the search matches any column value containing the text.
+	Filter func(expr string) (func(i int) bool, error)
+}
+
+// Run shows the browser until the user quits or picks an entry. It returns the
+// index of the entry picked for editing, or -1 if the user quit.
+func Run(cfg Config) (int, error) {
+	m := newModel(cfg)
+	final, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	if err != nil {
+		return -1, fmt.Errorf("browser failed: %w", err)
+	}
+	return final.(model).picked, nil
+}
+
+var (
+	titleStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+	detailStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			Padding(0, 1)
+	helpStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	errStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+)
+
+type model struct {
+	cfg     Config
+	table   table.Model
+	search  textinput.Model
+	visible []int // Indices of entries matching the search, in display order.
+	matches func(i int) bool
+	errMsg  string // Why the current search text could not be used.
+
+	searching bool
+	sortCol   int // -1 keeps the collection's own order.
+	sortDesc  bool
+
+	width, height int
+	picked        int
+}
+
+func newModel(cfg Config) model {
+	cols := make([]table.Column, len(cfg.Columns))
+	for i, c := range cfg.Columns {
+		cols[i] = table.Column{Title: c.Title, Width: c.Width}
+	}
+
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "search"
+
+	m := model{
+		cfg:     cfg,
+		table:   table.New(table.WithColumns(cols), table.WithFocused(true)),
+		search:  search,
+		sortCol: -1,
+		picked:  -1,
+	}
+	m.refresh()
+	return m
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.resize()
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "/":
+			m.searching = true
+			m.resize()
+			return m, m.search.Focus()
+		case "enter", "e":
+			if i, ok := m.current(); ok {
+				m.picked = i
+				return m, tea.Quit
+			}
+			return m, nil
+		case "s":
+			m.sortCol++
+			if m.sortCol >= len(m.cfg.Columns) {
+				m.sortCol = -1
+			}
+			m.refresh()
+			return m, nil
+		case "r":
+			m.sortDesc = !m.sortDesc
+			m.refresh()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.search.SetValue("")
+		fallthrough
+	case "enter":
+		m.searching = false
+		m.search.Blur()
+		m.refresh()
+		m.resize()
+		return m, nil
+	case "up", "down":
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	m.refresh()
+	return m, cmd
+}
+
+// refresh recomputes the visible entries from the search and sort state and
+// reloads the table rows.
+func (m *model) refresh() {
+	expr := strings.TrimSpace(m.search.Value())
+	switch {
+	case expr == "":
+		m.matches, m.errMsg = nil, ""
+	case m.cfg.Filter != nil:
+		// Keep the last working filter while the text is being typed.
+		if matches, err := m.cfg.Filter(expr); err != nil {
+			m.errMsg = err.Error()
+		} else {
+			m.matches, m.errMsg = matches, ""
+		}
+	default:
+		needle := strings.ToLower(expr)
+		m.matches = func(i int) bool {
+			return strings.Contains(strings.ToLower(m.searchText(i)), needle)
+		}
+	}
+
+	m.visible = m.visible[:0]
+	for i := 0; i < m.cfg.Len; i++ {
+		if m.matches == nil || m.matches(i) {
+			m.visible = append(m.visible, i)
+		}
+	}
+
+	if m.sortCol >= 0 {
+		col := m.cfg.Columns[m.sortCol]
+		less := col.Less
+		if less == nil {
+			less = func(a, b int) bool {
+				return strings.ToLower(col.Value(a)) < strings.ToLower(col.Value(b))
+			}
+		}
+		sort.SliceStable(m.visible, func(x, y int) bool {
+			if m.sortDesc {
+				return less(m.visible[y], m.visible[x])
+			}
+			return less(m.visible[x], m.visible[y])
+		})
+	} else if m.sortDesc {
+		for l, r := 0, len(m.visible)-1; l < r; l, r = l+1, r-1 {
+			m.visible[l], m.visible[r] = m.visible[r], m.visible[l]
+		}
+	}
+
+	rows := make([]table.Row, len(m.visible))
+	for r, i := range m.visible {
+		row := make(table.Row, len(m.cfg.Columns))
+		for c, col := range m.cfg.Columns {
+			row[c] = col.Value(i)
+		}
+		rows[r] = row
+	}
+
+	m.table.SetRows(rows)
+	if c := m.table.Cursor(); c < 0 || c >= len(rows) {
+		m.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+// current returns the entry under the cursor, if any.
+func (m model) current() (int, bool) {
+	c := m.table.Cursor()
+	if c < 0 || c >= len(m.visible) {
+		return -1, false
+	}
+	return m.visible[c], true
+}
+
+func (m model) searchText(i int) string {
+	values := make([]string, len(m.cfg.Columns))
+	for c, col := range m.cfg.Columns {
+		values[c] = col.Value(i)
+	}
+	return strings.Join(values, " ")
+}
+
+func (m *model) resize() {
+	// Title, search line, help line and the table header take up the rest.
+	h := m.height - 5
+	if h < 3 {
+		h = 3
+	}
+	m.table.SetHeight(h)
+}
+
+func (m model) tableWidth() int {
+	w := 0
+	for _, c := range m.cfg.Columns {
+		w += c.Width + 2
+	}
+	return w
+}
+
+func (m model) View() string {
+	var b strings.Builder
+
+	header := fmt.Sprintf("%s (%d/%d)", m.cfg.Title, len(m.visible), m.cfg.Len)
+	if m.sortCol >= 0 {
+		dir := "↑"
+		if m.sortDesc {
+			dir = "↓"
+		}
+		header += fmt.Sprintf(" · sorted by %s %s", m.cfg.Columns[m.sortCol].Title, dir)
+	}
+	b.WriteString(titleStyle.Render(header) + "\n")
+
+	if m.searching || m.search.Value() != "" {
+		line := m.search.View()
+		if m.errMsg != "" {
+			line += "  " + errStyle.Render(m.errMsg)
+		}
+		b.WriteString(line + "\n")
+	} else {
+		b.WriteString("\n")
+	}
+
+	detail := "No entries match."
+	if i, ok := m.current(); ok && m.cfg.Detail != nil {
+		detail = m.cfg.Detail(i)
+	}
+
+	detailWidth := m.width - m.tableWidth() - 6
+	if detailWidth < 20 {
+		detailWidth = 20
+	}
+	detailLines := strings.Split(lipgloss.NewStyle().Width(detailWidth).Render(detail), "\n")
+	if maxLines := m.table.Height() + 1; len(detailLines) > maxLines {
+		detailLines = append(detailLines[:maxLines-1], "…")
+	}
+	pane := detailStyle.Render(strings.Join(detailLines, "\n"))
+
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, m.table.View(), " ", pane) + "\n")
+
+	if m.searching {
+		b.WriteString(helpStyle.Render("type to filter • enter keep filter • esc clear • ↑/↓ move"))
+	} else {
+		b.WriteString(helpStyle.Render("↑/↓ move • / search • s sort column • r reverse • e/enter edit • q quit"))
+	}
+	return b.String()
+}
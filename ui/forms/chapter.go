@@ -0,0 +1,89 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// chapterAccessor edits a chapter label and number through a text input,
+// keeping the typed text like numberAccessor does.
+type chapterAccessor struct {
+	label   *models.ChapterLabel
+	chapter *float64
+	text    string
+}
+
+func (a *chapterAccessor) Get() string {
+	return a.text
+}
+
+func (a *chapterAccessor) Set(text string) {
+	a.text = text
+	if label, n, err := utils.ParseChapter(text); err == nil {
+		*a.label, *a.chapter = label, n
+	}
+}
+
+// chapterFields returns the inputs for a volume and a chapter. check, when
+// set, vets the chapter as typed, together with the volume entered above it.
+func chapterFields(title string, volume *uint32, label *models.ChapterLabel, chapter *float64, check func(models.ChapterPoint) error) []huh.Field {
+	return []huh.Field{
+		NumberInput("Volume (optional):", volume, nil).
+			Description("Leave empty for works without volumes."),
+		chapterInput(title, volume, label, chapter, check),
+	}
+}
+
+func chapterInput(title string, volume *uint32, label *models.ChapterLabel, chapter *float64, check func(models.ChapterPoint) error) *huh.Input {
+	text := utils.ChapterText(models.Review{Label: *label, Chapter: *chapter})
+	return huh.NewInput().
+		Title(title).
+		Description(fmt.Sprintf("A number such as 10 or 10.5, or %s with an optional number.",
+			strings.Join(chapterLabelNames(), ", "))).
+		Accessor(&chapterAccessor{label: label, chapter: chapter, text: text}).
+		Validate(func(s string) error {
+			l, n, err := utils.ParseChapter(s)
+			if err != nil || check == nil {
+				return err
+			}
+			return check(models.ChapterPoint{Volume: *volume, Label: l, Chapter: n})
+		})
+}
+
+// ChapterFields returns the inputs for the volume and chapter of a review whose
+// work is already set. The chapter may be fractional or a special label such as
+// "Prologue" or "Extra 2", and may not repeat one reviewed already.
+func ChapterFields(reviews []models.Review, r *models.Review) []huh.Field {
+	return chapterFields("Chapter:", &r.Volume, &r.Label, &r.Chapter, func(p models.ChapterPoint) error {
+		candidate := *r
+		candidate.Volume, candidate.Label, candidate.Chapter = p.Volume, p.Label, p.Chapter
+		if utils.ChapterTaken(reviews, candidate) {
+			return fmt.Errorf("%s of this work is already reviewed", utils.FormatChapter(candidate))
+		}
+		return nil
+	})
+}
+
+// PointFields returns the inputs for a chapter of a work, such as where a
+// story arc starts.
+func PointFields(title string, p *models.ChapterPoint, check func(models.ChapterPoint) error) []huh.Field {
+	return chapterFields(title, &p.Volume, &p.Label, &p.Chapter, check)
+}
+
+// ChapterInput returns an input for the chapter of p, leaving its volume as it
+// is.
+func ChapterInput(title string, p *models.ChapterPoint, check func(models.ChapterPoint) error) *huh.Input {
+	return chapterInput(title, &p.Volume, &p.Label, &p.Chapter, check)
+}
+
+func chapterLabelNames() []string {
+	names := make([]string, len(utils.ChapterLabels))
+	for i, l := range utils.ChapterLabels {
+		names[i] = string(l)
+	}
+	return names
+}
@@ -0,0 +1,47 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+	"utilodactyl/query"
+
+	"github.com/charmbracelet/huh"
+)
+
+// filterPromptThreshold is the collection size above which pickers first ask
+// for a query to narrow the list down.
+const filterPromptThreshold = 10
+
+// FilterPrompt lets the user narrow items down with a query before picking one
+// of them. Small collections and empty answers are returned unfiltered.
+func FilterPrompt[T any](items []T, noun string) ([]T, error) {
+	if len(items) <= filterPromptThreshold {
+		return items, nil
+	}
+
+	var expr string
+	filtered := items
+	err := Run(huh.NewInput().
+		Title(fmt.Sprintf("Filter %s (optional):", noun)).
+		Description("e.g. status:Reading rating>=4 tag:\"found family\" author~sanderson").
+		Value(&expr).
+		Validate(func(s string) error {
+			if strings.TrimSpace(s) == "" {
+				filtered = items
+				return nil
+			}
+			matched, err := query.Filter(items, s)
+			if err != nil {
+				return err
+			}
+			if len(matched) == 0 {
+				return fmt.Errorf("no %s match", noun)
+			}
+			filtered = matched
+			return nil
+		}))
+	if err != nil {
+		return nil, err
+	}
+	return filtered, nil
+}
@@ -0,0 +1,62 @@
+// Package forms holds form components shared by the collection add and edit
+// flows.
+package forms
+
+import (
+	"fmt"
+	"strings"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// EditLinks lets the user add, edit, delete and reorder links. It works on a
+// copy, so the returned slice should replace the original once it succeeds.
+func EditLinks(links []models.ItemLink) ([]models.ItemLink, error) {
+	return listEditor[models.ItemLink]{
+		noun:  "link",
+		title: "Links:",
+		label: LinkLabel,
+		form: func(link *models.ItemLink, title string) error {
+			if err := linkForm(link, title).Run(); err != nil {
+				return err
+			}
+			*link = trimLink(*link)
+			return nil
+		},
+	}.run(links)
+}
+
+// linkForm builds a form editing the title and URL of link in place.
+func linkForm(link *models.ItemLink, title string) *huh.Form {
+	return NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Link title:").
+				Value(&link.Title).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return fmt.Errorf("link title cannot be empty")
+					}
+					return nil
+				}),
+			huh.NewInput().
+				Title("Link URL:").
+				Value(&link.URL).
+				Validate(utils.ValidateURL),
+		).Title(title),
+	)
+}
+
+// LinkLabel renders a link as a single line.
+func LinkLabel(link models.ItemLink) string {
+	return fmt.Sprintf("%s → %s", link.Title, link.URL)
+}
+
+func trimLink(link models.ItemLink) models.ItemLink {
+	return models.ItemLink{
+		Title: strings.TrimSpace(link.Title),
+		URL:   strings.TrimSpace(link.URL),
+	}
+}
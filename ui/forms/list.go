@@ -0,0 +1,144 @@
+package forms
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+)
+
+// Special choices in a list editor, kept negative so they never collide with
+// an item index.
+const (
+	choiceAdd  = -1
+	choiceDone = -2
+)
+
+type itemAction int
+
+const (
+	itemEdit itemAction = iota
+	itemMoveUp
+	itemMoveDown
+	itemDelete
+	itemBack
+)
+
+// listEditor edits an ordered list of items one at a time, such as the links
+// or contributors of an entry.
+type listEditor[T any] struct {
+	noun  string                            // Singular name of an item, e.g. "link".
+	title string                            // Title of the list, e.g. "Links:".
+	label func(T) string                    // Renders an item as a single line.
+	form  func(item *T, title string) error // Edits an item in place.
+	done  func([]T) error                   // Checks the list before leaving; nil accepts any.
+}
+
+// run lets the user add, edit, delete and reorder items. It works on a copy,
+// so the returned slice should replace the original once it succeeds.
+func (e listEditor[T]) run(items []T) ([]T, error) {
+	items = append([]T(nil), items...)
+
+	cursor := choiceAdd
+	problem := ""
+	for {
+		options := make([]huh.Option[int], 0, len(items)+2)
+		for i, item := range items {
+			options = append(options, huh.NewOption(fmt.Sprintf("%d. %s", i+1, e.label(item)), i))
+		}
+		options = append(options,
+			huh.NewOption(fmt.Sprintf("➕ Add a %s", e.noun), choiceAdd),
+			huh.NewOption("✅ Done", choiceDone),
+		)
+
+		description := fmt.Sprintf("%d %s(s). Pick one to edit, move or delete it.", len(items), e.noun)
+		if problem != "" {
+			description = "⚠️ " + problem
+		}
+		err := Run(huh.NewSelect[int]().
+			Title(e.title).
+			Description(description).
+			Options(options...).
+			Value(&cursor))
+		if err != nil {
+			return nil, err
+		}
+
+		problem = ""
+		switch cursor {
+		case choiceDone:
+			if e.done != nil {
+				if err := e.done(items); err != nil {
+					problem = err.Error()
+					continue
+				}
+			}
+			return items, nil
+		case choiceAdd:
+			var item T
+			if err := e.form(&item, fmt.Sprintf("New %s", e.noun)); err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		default:
+			if cursor, err = e.editItem(&items, cursor); err != nil {
+				return nil, err
+			}
+			if cursor >= len(items) {
+				cursor = choiceAdd
+			}
+		}
+	}
+}
+
+// editItem asks what to do with the i-th item and does it, returning the index
+// the list should return to.
+func (e listEditor[T]) editItem(itemsPtr *[]T, i int) (int, error) {
+	items := *itemsPtr
+	options := []huh.Option[itemAction]{huh.NewOption("Edit", itemEdit)}
+	if i > 0 {
+		options = append(options, huh.NewOption("Move up", itemMoveUp))
+	}
+	if i < len(items)-1 {
+		options = append(options, huh.NewOption("Move down", itemMoveDown))
+	}
+	options = append(options,
+		huh.NewOption("Delete", itemDelete),
+		huh.NewOption("Back", itemBack),
+	)
+
+	action := itemEdit
+	err := Run(huh.NewSelect[itemAction]().
+		Title(e.label(items[i])).
+		Options(options...).
+		Value(&action))
+	if err != nil {
+		return i, err
+	}
+
+	switch action {
+	case itemEdit:
+		item := items[i]
+		if err := e.form(&item, fmt.Sprintf("Edit %s", e.noun)); err != nil {
+			return i, err
+		}
+		items[i] = item
+	case itemMoveUp:
+		items[i-1], items[i] = items[i], items[i-1]
+		return i - 1, nil
+	case itemMoveDown:
+		items[i+1], items[i] = items[i], items[i+1]
+		return i + 1, nil
+	case itemDelete:
+		var confirm bool
+		err := Run(huh.NewConfirm().
+			Title(fmt.Sprintf("Delete %s?", e.label(items[i]))).
+			Value(&confirm))
+		if err != nil {
+			return i, err
+		}
+		if confirm {
+			*itemsPtr = append(items[:i], items[i+1:]...)
+		}
+	}
+	return i, nil
+}
@@ -0,0 +1,71 @@
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// numberAccessor edits a number through a text input. It keeps the typed text,
+// so validation sees exactly what was entered rather than the last number that
+// parsed.
+type numberAccessor struct {
+	value *uint32
+	text  string
+}
+
+func (a *numberAccessor) Get() string {
+	return a.text
+}
+
+func (a *numberAccessor) Set(text string) {
+	a.text = text
+	if n, err := parseNumber(text); err == nil {
+		*a.value = n
+	}
+}
+
+// parseNumber parses a whole number; empty input is 0.
+func parseNumber(text string) (uint32, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(text, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("enter a whole number")
+	}
+	return uint32(n), nil
+}
+
+// NumberInput returns an input for a whole number. When limit is set, the
+// number may not exceed what it returns; a limit of 0 means no limit.
+func NumberInput(title string, value *uint32, limit func() uint32) *huh.Input {
+	text := ""
+	if *value != 0 {
+		text = strconv.FormatUint(uint64(*value), 10)
+	}
+	return huh.NewInput().
+		Title(title).
+		Accessor(&numberAccessor{value: value, text: text}).
+		Validate(func(s string) error {
+			n, err := parseNumber(s)
+			if err != nil {
+				return err
+			}
+			if limit != nil {
+				if max := limit(); max > 0 && n > max {
+					return fmt.Errorf("must be at most %d", max)
+				}
+			}
+			return nil
+		})
+}
+
+// PercentInput returns an input for a percentage from 0 to 100.
+func PercentInput(title string, value *uint32) *huh.Input {
+	return NumberInput(title, value, func() uint32 { return 100 }).
+		Description("0–100; leave empty for 0.")
+}
@@ -0,0 +1,81 @@
+package forms
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// PersonInput returns an input for a person's name that autocompletes from the
+// people registry, preferring those already credited with role.
+func PersonInput(title string, people []models.Person, role models.Role, value *string) *huh.Input {
+	suggestions := utils.PersonSuggestions(people, role)
+	if len(suggestions) == 0 {
+		suggestions = utils.PersonSuggestions(people, "")
+	}
+	return huh.NewInput().
+		Title(title).
+		Value(value).
+		Suggestions(suggestions).
+		Validate(func(s string) error {
+			if strings.TrimSpace(s) == "" {
+				return fmt.Errorf("name cannot be empty")
+			}
+			return nil
+		})
+}
+
+// EditContributors lets the user credit people with roles, in order. Names are
+// resolved against the registry, and new ones are added to it. At least one
+// contributor must have the first of roles. It works on a copy, so the
+// returned slice should replace the original once it succeeds.
+func EditContributors(people *[]models.Person, contributors []models.Contributor, roles []models.Role) ([]models.Contributor, error) {
+	return listEditor[models.Contributor]{
+		noun:  "contributor",
+		title: "Contributors:",
+		label: func(c models.Contributor) string {
+			return fmt.Sprintf("%s (%s)", utils.PersonName(*people, c.PersonID), c.Role)
+		},
+		form: func(c *models.Contributor, title string) error {
+			return contributorForm(people, c, roles, title)
+		},
+		done: func(list []models.Contributor) error {
+			if !slices.ContainsFunc(list, func(c models.Contributor) bool { return c.Role == roles[0] }) {
+				return fmt.Errorf("add at least one %s", roles[0])
+			}
+			return nil
+		},
+	}.run(contributors)
+}
+
+func contributorForm(people *[]models.Person, c *models.Contributor, roles []models.Role, title string) error {
+	name := utils.PersonName(*people, c.PersonID)
+	role := c.Role
+	if role == "" {
+		role = roles[0]
+	}
+
+	options := make([]huh.Option[models.Role], len(roles))
+	for i, r := range roles {
+		options[i] = huh.NewOption(string(r), r)
+	}
+
+	err := NewForm(huh.NewGroup(
+		huh.NewSelect[models.Role]().
+			Title("Role:").
+			Options(options...).
+			Value(&role),
+		PersonInput("Name:", *people, "", &name),
+	).Title(title)).Run()
+	if err != nil {
+		return err
+	}
+
+	c.PersonID = utils.ResolvePerson(people, name, role)
+	c.Role = role
+	return nil
+}
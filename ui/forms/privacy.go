@@ -0,0 +1,39 @@
+package forms
+
+import (
+	"slices"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// PrivacyGroups edit whether an entry is published and which of its fields
+// are kept private. Fields the collection already keeps private are not
+// offered.
+func PrivacyGroups(fields []utils.PrivateField, configured []string, private *bool, privateFields *[]string) []*huh.Group {
+	groups := []*huh.Group{
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Keep private?").
+				Description("Private entries stay local, or go to the private release only.").
+				Value(private),
+		),
+	}
+
+	var options []huh.Option[string]
+	for _, f := range fields {
+		if !slices.Contains(configured, f.Name) {
+			options = append(options, huh.NewOption(f.Name, f.Name).Selected(slices.Contains(*privateFields, f.Name)))
+		}
+	}
+	if len(options) == 0 {
+		return groups
+	}
+	return append(groups, huh.NewGroup(
+		huh.NewMultiSelect[string]().
+			Title("Private fields:").
+			Description("Left out of the public release.").
+			Options(options...).
+			Value(privateFields),
+	).WithHideFunc(func() bool { return *private }))
+}
@@ -0,0 +1,39 @@
+package forms
+
+import (
+	"fmt"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// BookProgressGroup edits a book's format, length and position. The length
+// and position are in minutes for audiobooks and in pages otherwise.
+func BookProgressGroup(b *models.Book) *huh.Group {
+	if b.Format == "" {
+		b.Format = models.FormatPhysical
+	}
+	options := make([]huh.Option[models.BookFormat], len(utils.BookFormats))
+	for i, f := range utils.BookFormats {
+		options[i] = huh.NewOption(string(f), f)
+	}
+
+	return huh.NewGroup(
+		huh.NewSelect[models.BookFormat]().
+			Title("Format:").
+			Options(options...).
+			Value(&b.Format),
+		NumberInput("", &b.Length, nil).
+			TitleFunc(func() string {
+				return fmt.Sprintf("Length (%s, optional):", utils.BookUnit(b.Format))
+			}, &b.Format),
+		NumberInput("", &b.Position, func() uint32 { return b.Length }).
+			TitleFunc(func() string {
+				if b.Format == models.FormatAudiobook {
+					return "Minutes listened:"
+				}
+				return "Current page:"
+			}, &b.Format),
+	)
+}
@@ -0,0 +1,56 @@
+package forms
+
+import (
+	"utilodactyl/models"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// ratingAccessor edits a rating through a text input, keeping the typed text
+// like numberAccessor does.
+type ratingAccessor struct {
+	value *models.Rating
+	scale utils.Scale
+	text  string
+}
+
+func (a *ratingAccessor) Get() string {
+	return a.text
+}
+
+func (a *ratingAccessor) Set(text string) {
+	a.text = text
+	if r, err := a.scale.Parse(text); err == nil {
+		*a.value = r
+	}
+}
+
+// RatingField returns a field for a rating on the given scale: a select for
+// short scales, and a number input for the 100-point one.
+func RatingField(scale utils.Scale, value *models.Rating) huh.Field {
+	title := "Rating (" + scale.Range() + "):"
+	ratings := scale.Ratings()
+	if len(ratings) > 10 {
+		text := ""
+		if *value != 0 {
+			text = utils.FormatNumber(*value)
+		}
+		return huh.NewInput().
+			Title(title).
+			Accessor(&ratingAccessor{value: value, scale: scale, text: text}).
+			Validate(func(s string) error {
+				_, err := scale.Parse(s)
+				return err
+			})
+	}
+
+	options := make([]huh.Option[models.Rating], len(ratings))
+	for i, r := range ratings {
+		options[i] = huh.NewOption(utils.FormatNumber(r), r)
+	}
+	return huh.NewSelect[models.Rating]().
+		Title(title).
+		Options(options...).
+		Value(value)
+}
@@ -0,0 +1,25 @@
+package forms
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/huh"
+)
+
+// KeyMap is the keymap shared by every form in the app. Escape leaves a form
+// like ctrl+c does, so from an action it goes back to the menu. Because of
+// that, escape no longer clears a select's filter; enter accepts it instead.
+func KeyMap() *huh.KeyMap {
+	keymap := huh.NewDefaultKeyMap()
+	keymap.Quit = key.NewBinding(key.WithKeys("ctrl+c", "esc"))
+	return keymap
+}
+
+// NewForm is huh.NewForm with the app's keymap.
+func NewForm(groups ...*huh.Group) *huh.Form {
+	return huh.NewForm(groups...).WithKeyMap(KeyMap())
+}
+
+// Run runs a single field on its own, like huh.Run, with the app's keymap.
+func Run(field huh.Field) error {
+	return NewForm(huh.NewGroup(field)).WithShowHelp(false).Run()
+}
@@ -0,0 +1,44 @@
+package forms
+
+import (
+	"time"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// publishAtAccessor edits an optional publish time through a text input,
+// keeping the typed text like numberAccessor does.
+type publishAtAccessor struct {
+	value **time.Time
+	text  string
+}
+
+func (a *publishAtAccessor) Get() string {
+	return a.text
+}
+
+func (a *publishAtAccessor) Set(text string) {
+	a.text = text
+	if t, err := utils.ParsePublishAt(text); err == nil {
+		*a.value = t
+	}
+}
+
+// ScheduleGroup edits whether an entry is a draft and when it goes public.
+func ScheduleGroup(draft *bool, publishAt **time.Time) *huh.Group {
+	return huh.NewGroup(
+		huh.NewConfirm().
+			Title("Draft?").
+			Description("Drafts stay off the site until this is cleared.").
+			Value(draft),
+		huh.NewInput().
+			Title("Publish at (optional):").
+			Description("Local time as "+utils.PublishAtLayout+", or a date alone. Leave empty to publish right away.").
+			Accessor(&publishAtAccessor{value: publishAt, text: utils.FormatPublishAt(*publishAt)}).
+			Validate(func(s string) error {
+				_, err := utils.ParsePublishAt(s)
+				return err
+			}),
+	)
+}
@@ -0,0 +1,73 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// positionAccessor edits a book's place in its series through a text input,
+// keeping the typed text so validation sees what was entered.
+type positionAccessor struct {
+	book *models.Book
+	text string
+}
+
+func (a *positionAccessor) Get() string {
+	return a.text
+}
+
+func (a *positionAccessor) Set(text string) {
+	a.text = text
+	if p, err := utils.ParseSeriesPosition(text); err == nil {
+		a.book.SeriesPosition = p
+	}
+}
+
+// SeriesPositionInput returns an input for a book's place in its series. It
+// may be left empty only while the book is in no series.
+func SeriesPositionInput(title string, b *models.Book) *huh.Input {
+	text := ""
+	if b.SeriesPosition != 0 {
+		text = utils.FormatSeriesPosition(b.SeriesPosition)
+	}
+	return huh.NewInput().
+		Title(title).
+		Accessor(&positionAccessor{book: b, text: text}).
+		Validate(func(s string) error {
+			if b.SeriesID == 0 && strings.TrimSpace(s) == "" {
+				return nil
+			}
+			_, err := utils.ParseSeriesPosition(s)
+			return err
+		})
+}
+
+// BookSeriesGroup picks the series of a book and its position in it. books is
+// the rest of the collection, used to suggest the next free position. The
+// position is left as typed when no series is picked, so callers should clear
+// it then. The group is hidden while there are no series.
+func BookSeriesGroup(b *models.Book, series []models.Series, books []models.Book) *huh.Group {
+	options := []huh.Option[uint32]{huh.NewOption("None", uint32(0))}
+	for _, s := range series {
+		options = append(options, huh.NewOption(s.Name, s.ID))
+	}
+
+	return huh.NewGroup(
+		huh.NewSelect[uint32]().
+			Title("Series:").
+			Options(options...).
+			Value(&b.SeriesID),
+		SeriesPositionInput("Position in series:", b).
+			DescriptionFunc(func() string {
+				if b.SeriesID == 0 {
+					return "Not in a series; leave empty."
+				}
+				return fmt.Sprintf("e.g. 3, or 2.5 for a novella. Next free: %s",
+					utils.FormatSeriesPosition(utils.NextPosition(books, b.SeriesID)))
+			}, &b.SeriesID),
+	).WithHide(len(series) == 0)
+}
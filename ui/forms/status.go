@@ -0,0 +1,41 @@
+package forms
+
+import (
+	"fmt"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// StatusSelect offers the statuses an entry in status current may move to.
+func StatusSelect(title string, statuses []models.StatusConfig, current models.Status, value *models.Status) *huh.Select[models.Status] {
+	next := utils.NextStatuses(statuses, current)
+	options := make([]huh.Option[models.Status], len(next))
+	for i, s := range next {
+		options[i] = huh.NewOption(string(s), s)
+	}
+	if *value == "" {
+		*value = next[0]
+	}
+	return huh.NewSelect[models.Status]().
+		Title(title).
+		Options(options...).
+		Value(value)
+}
+
+// AskStatusReason asks why an entry moved to status, for statuses configured
+// to ask. It returns "" for the others.
+func AskStatusReason(statuses []models.StatusConfig, status models.Status) (string, error) {
+	def, ok := utils.FindStatus(statuses, status)
+	if !ok || !def.AskReason {
+		return "", nil
+	}
+
+	var reason string
+	err := Run(huh.NewInput().
+		Title(fmt.Sprintf("Why is it %s?", status)).
+		Description("Optional; kept in the status history.").
+		Value(&reason))
+	return reason, err
+}
@@ -0,0 +1,118 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// StringSet edits a set of strings such as genres or tags: values can be
+// picked from the ones already used in the collection, and new ones typed in
+// the same step, comma-separated. Values are trimmed, and values differing only
+// in case are treated as the same, keeping the spelling already in use.
+type StringSet struct {
+	noun     string
+	known    []string
+	vocab    []models.VocabTerm
+	value    *[]string
+	selected []string
+	custom   string
+}
+
+// NewStringSet returns a set editor for value, offering the known values of
+// the collection. noun is the plural name shown to the user, e.g. "genres".
+func NewStringSet(noun string, known []string, value *[]string) *StringSet {
+	known = utils.MergeStrings(known, *value)
+	utils.SortFold(known)
+
+	s := &StringSet{noun: noun, known: known, value: value}
+	s.selectCurrent()
+	return s
+}
+
+// WithVocabulary offers the canonical terms of a vocabulary and replaces
+// aliases with the terms they stand for.
+func (s *StringSet) WithVocabulary(terms []models.VocabTerm) *StringSet {
+	s.vocab = terms
+	s.known = utils.NormalizeTerms(terms, append(utils.TermNames(terms), s.known...))
+	utils.SortFold(s.known)
+	s.selectCurrent()
+	return s
+}
+
+func (s *StringSet) selectCurrent() {
+	s.selected = nil
+	for _, v := range utils.MergeStrings(*s.value) {
+		s.selected = append(s.selected, s.canonical(v))
+	}
+	s.selected = utils.MergeStrings(s.selected)
+}
+
+// canonical returns the vocabulary term or known spelling of v, or v itself
+// if it is new.
+func (s *StringSet) canonical(v string) string {
+	if term, ok := utils.CanonicalTerm(s.vocab, v); ok {
+		return term
+	}
+	for _, k := range s.known {
+		if strings.EqualFold(k, v) {
+			return k
+		}
+	}
+	return v
+}
+
+// Group returns the form group for the set. Apply must be called after the
+// form has run to store the result.
+func (s *StringSet) Group() *huh.Group {
+	var fields []huh.Field
+	if len(s.known) > 0 {
+		fields = append(fields, huh.NewMultiSelect[string]().
+			Title(fmt.Sprintf("Select %s:", s.noun)).
+			Options(huh.NewOptions(s.known...)...).
+			Value(&s.selected).
+			Filterable(true).
+			Height(min(len(s.known)+2, 12)))
+	}
+	fields = append(fields, huh.NewInput().
+		Title(fmt.Sprintf("New %s:", s.noun)).
+		Description("Comma-separated; leave empty to add none.").
+		Value(&s.custom))
+	return huh.NewGroup(fields...)
+}
+
+// Apply stores the selected and newly typed values.
+func (s *StringSet) Apply() {
+	custom := utils.SplitList(s.custom)
+	for i, v := range custom {
+		custom[i] = s.canonical(v)
+	}
+	*s.value = utils.MergeStrings(s.selected, custom)
+}
+
+// Run edits the set in a form of its own.
+func (s *StringSet) Run() error {
+	if err := NewForm(s.Group()).Run(); err != nil {
+		return err
+	}
+	s.Apply()
+	return nil
+}
+
+// EditStringSets edits several sets in one form, one page per set.
+func EditStringSets(sets ...*StringSet) error {
+	groups := make([]*huh.Group, len(sets))
+	for i, s := range sets {
+		groups[i] = s.Group()
+	}
+	if err := NewForm(groups...).Run(); err != nil {
+		return err
+	}
+	for _, s := range sets {
+		s.Apply()
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package forms
+
+import (
+	"slices"
+	"strings"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// warningAccessor edits the severity an entry's warnings give one category,
+// adding or removing the warning as the severity is set or cleared.
+type warningAccessor struct {
+	warnings *[]models.ContentWarning
+	category string
+}
+
+func (a *warningAccessor) Get() models.Severity {
+	severity, _ := utils.FindWarning(*a.warnings, a.category)
+	return severity
+}
+
+func (a *warningAccessor) Set(severity models.Severity) {
+	i := slices.IndexFunc(*a.warnings, func(w models.ContentWarning) bool { return w.Category == a.category })
+	switch {
+	case severity == "" && i >= 0:
+		*a.warnings = slices.Delete(*a.warnings, i, i+1)
+	case severity == "":
+	case i >= 0:
+		(*a.warnings)[i].Severity = severity
+	default:
+		*a.warnings = append(*a.warnings, models.ContentWarning{Category: a.category, Severity: severity})
+	}
+}
+
+// ContentWarningGroups edit an entry's content warnings, one severity per
+// category of the taxonomy. Entries given no warnings are asked whether they
+// are explicit instead, as they were before warnings existed; the caller
+// derives the flag with utils.DeriveExplicit once the form is done.
+func ContentWarningGroups(taxonomy []models.WarningCategory, warnings *[]models.ContentWarning, explicit *bool) []*huh.Group {
+	severities := []huh.Option[models.Severity]{huh.NewOption("none", models.Severity(""))}
+	for _, s := range utils.Severities {
+		severities = append(severities, huh.NewOption(string(s), s))
+	}
+
+	fields := make([]huh.Field, len(taxonomy))
+	for i, c := range taxonomy {
+		fields[i] = huh.NewSelect[models.Severity]().
+			Title(capitalize(c.Name) + ":").
+			Options(severities...).
+			Inline(true).
+			Accessor(&warningAccessor{warnings: warnings, category: c.Name})
+	}
+
+	return []*huh.Group{
+		huh.NewGroup(fields...).
+			Title("Content warnings").
+			Description("Leave a category at none when it does not apply."),
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Explicit Content:").
+				Value(explicit),
+		).WithHideFunc(func() bool { return len(*warnings) > 0 }),
+	}
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
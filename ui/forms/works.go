@@ -0,0 +1,82 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+	"utilodactyl/models"
+	"utilodactyl/utils"
+
+	"github.com/charmbracelet/huh"
+)
+
+// newWork is the picker choice for adding a serial or webcomic. No work can
+// have its ID, since IDs count up from 1.
+var newWork = utils.WorkRef{WorkID: ^uint32(0)}
+
+// PickWork asks which work a review is for: a serial or webcomic from the
+// works list, a book, or a new serial or webcomic, which is added to works.
+// current is preselected.
+func PickWork(books []models.Book, works *[]models.Work, current utils.WorkRef) (utils.WorkRef, error) {
+	options := make([]huh.Option[utils.WorkRef], 0, len(*works)+len(books)+1)
+	for _, w := range *works {
+		options = append(options, huh.NewOption(fmt.Sprintf("📰 %s (%s)", w.Title, w.Kind), utils.WorkRef{WorkID: w.ID}))
+	}
+	for _, b := range books {
+		options = append(options, huh.NewOption("📖 "+utils.BookLabel(b), utils.WorkRef{BookID: b.ID}))
+	}
+	options = append(options, huh.NewOption("➕ New serial or webcomic", newWork))
+
+	ref := current
+	if ref == (utils.WorkRef{}) {
+		ref = newWork
+	}
+	err := Run(huh.NewSelect[utils.WorkRef]().
+		Title("Which work is the review for?").
+		Options(options...).
+		Value(&ref).
+		Height(min(len(options)+2, 14)))
+	if err != nil {
+		return current, err
+	}
+	if ref != newWork {
+		return ref, nil
+	}
+
+	work := models.Work{ID: utils.NextWorkID(*works), Kind: models.WorkSerial}
+	kinds := make([]huh.Option[models.WorkKind], len(utils.WorkKinds))
+	for i, k := range utils.WorkKinds {
+		kinds[i] = huh.NewOption(string(k), k)
+	}
+	err = NewForm(huh.NewGroup(
+		huh.NewInput().
+			Title("Title:").
+			Value(&work.Title).
+			Validate(func(s string) error {
+				if strings.TrimSpace(s) == "" {
+					return fmt.Errorf("title cannot be empty")
+				}
+				return nil
+			}),
+		huh.NewSelect[models.WorkKind]().
+			Title("Kind:").
+			Options(kinds...).
+			Value(&work.Kind),
+		huh.NewInput().
+			Title("URL (optional):").
+			Value(&work.URL).
+			Validate(func(s string) error {
+				if strings.TrimSpace(s) == "" {
+					return nil
+				}
+				return utils.ValidateURL(s)
+			}),
+	).Title("New work")).Run()
+	if err != nil {
+		return current, err
+	}
+
+	work.Title = strings.TrimSpace(work.Title)
+	work.URL = strings.TrimSpace(work.URL)
+	*works = append(*works, work)
+	return utils.WorkRef{WorkID: work.ID}, nil
+}
@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"utilodactyl/models"
+)
+
+// ArcsFile holds the story arcs that group the chapter reviews of a work.
+const ArcsFile = "arcs.json"
+
+func LoadArcs() ([]models.Arc, error) {
+	return readJSONFile[models.Arc](ArcsFile)
+}
+
+func SaveArcs(arcs []models.Arc) error {
+	return writeJSONFile(ArcsFile, arcs)
+}
+
+func GenerateArcID() (uint32, error) {
+	return generateNextID(LoadArcs, func(a models.Arc) uint32 { return a.ID })
+}
+
+// ArcWork returns the work an arc belongs to.
+func ArcWork(a models.Arc) WorkRef {
+	return WorkRef{BookID: a.BookID, WorkID: a.WorkID}
+}
+
+// SetArcWork points an arc at a work.
+func SetArcWork(a *models.Arc, ref WorkRef) {
+	a.BookID, a.WorkID = ref.BookID, ref.WorkID
+}
+
+// ChapterPointOf returns the chapter a review is for.
+func ChapterPointOf(r models.Review) models.ChapterPoint {
+	return models.ChapterPoint{Volume: r.Volume, Label: r.Label, Chapter: r.Chapter}
+}
+
+// pointReview turns a chapter into a review of it, so the chapter helpers
+// apply to it.
+func pointReview(p models.ChapterPoint) models.Review {
+	return models.Review{Volume: p.Volume, Label: p.Label, Chapter: p.Chapter}
+}
+
+// ComparePoints orders chapters like CompareChapters.
+func ComparePoints(a, b models.ChapterPoint) int {
+	return CompareChapters(pointReview(a), pointReview(b))
+}
+
+// FormatPoint renders a chapter like FormatChapter.
+func FormatPoint(p models.ChapterPoint) string {
+	return FormatChapter(pointReview(p))
+}
+
+// ArcRange renders the chapters an arc spans, e.g. "Ch. 1 – Ch. 11".
+func ArcRange(a models.Arc) string {
+	return FormatPoint(a.First) + " – " + FormatPoint(a.Last)
+}
+
+// InArc reports whether a review is for a chapter of the arc.
+func InArc(a models.Arc, r models.Review) bool {
+	if ReviewWork(r) != ArcWork(a) {
+		return false
+	}
+	p := ChapterPointOf(r)
+	return ComparePoints(a.First, p) <= 0 && ComparePoints(p, a.Last) <= 0
+}
+
+// FindArc returns the arc a review falls in.
+func FindArc(arcs []models.Arc, r models.Review) (models.Arc, bool) {
+	for _, a := range arcs {
+		if InArc(a, r) {
+			return a, true
+		}
+	}
+	return models.Arc{}, false
+}
+
+// ArcOverlap returns another arc of the same work sharing chapters with a.
+func ArcOverlap(arcs []models.Arc, a models.Arc) (models.Arc, bool) {
+	for _, other := range arcs {
+		if other.ID == a.ID || ArcWork(other) != ArcWork(a) {
+			continue
+		}
+		if ComparePoints(a.First, other.Last) <= 0 && ComparePoints(other.First, a.Last) <= 0 {
+			return other, true
+		}
+	}
+	return models.Arc{}, false
+}
+
+// GroupArcsByWork orders arcs by work title, then by their first chapter.
+func GroupArcsByWork(arcs []models.Arc, books []models.Book, works []models.Work) []models.Arc {
+	grouped := slices.Clone(arcs)
+	slices.SortStableFunc(grouped, func(a, b models.Arc) int {
+		ra, rb := ArcWork(a), ArcWork(b)
+		return cmp.Or(
+			cmp.Compare(strings.ToLower(WorkTitle(books, works, ra)), strings.ToLower(WorkTitle(books, works, rb))),
+			cmp.Compare(ra.BookID, rb.BookID),
+			cmp.Compare(ra.WorkID, rb.WorkID),
+			ComparePoints(a.First, b.First),
+		)
+	})
+	return grouped
+}
+
+// ArcStats sums up the reviews of an arc. Trend is the change in rating over
+// the arc on a straight line fitted through its reviews in chapter order, so
+// 1.5 means the arc ends about one and a half points better than it starts.
+// Best and Worst are the earliest chapters with the highest and lowest rating.
+type ArcStats struct {
+	Count   int
+	Average float64
+	Trend   float64
+	Best    models.Review
+	Worst   models.Review
+}
+
+// ComputeArcStats sums up the reviews falling in an arc. It reports false when
+// none do.
+func ComputeArcStats(a models.Arc, reviews []models.Review) (ArcStats, bool) {
+	var in []models.Review
+	for _, r := range reviews {
+		if InArc(a, r) {
+			in = append(in, r)
+		}
+	}
+	if len(in) == 0 {
+		return ArcStats{}, false
+	}
+	slices.SortStableFunc(in, CompareChapters)
+
+	stats := ArcStats{Count: len(in), Best: in[0], Worst: in[0]}
+	var sum float64
+	for _, r := range in {
+		sum += float64(r.Rating)
+		if r.Rating > stats.Best.Rating {
+			stats.Best = r
+		}
+		if r.Rating < stats.Worst.Rating {
+			stats.Worst = r
+		}
+	}
+	stats.Average = sum / float64(len(in))
+
+	// Least-squares slope of rating against position in the arc.
+	n := float64(len(in))
+	meanX := (n - 1) / 2
+	var num, den float64
+	for i, r := range in {
+		dx := float64(i) - meanX
+		num += dx * (float64(r.Rating) - stats.Average)
+		den += dx * dx
+	}
+	if den > 0 {
+		stats.Trend = num / den * (n - 1)
+	}
+	return stats, true
+}
+
+// TrendLabel describes a trend in words, treating changes under a tenth of the
+// scale as steady.
+func TrendLabel(trend float64, scale Scale) string {
+	switch {
+	case trend >= scale.Max/10:
+		return "rising"
+	case trend <= -scale.Max/10:
+		return "falling"
+	default:
+		return "steady"
+	}
+}
+
+// FormatArcStats renders the summary of an arc on one line, with ratings on
+// the given scale.
+func FormatArcStats(s ArcStats, scale Scale) string {
+	line := fmt.Sprintf("%d chapter(s) · avg %.1f/%s", s.Count, s.Average, FormatNumber(models.Rating(scale.Max)))
+	if s.Count > 1 {
+		line += fmt.Sprintf(" · %s (%+.1f) · best %s (%s) · worst %s (%s)",
+			TrendLabel(s.Trend, scale), s.Trend,
+			FormatChapter(s.Best), scale.Format(s.Best.Rating), FormatChapter(s.Worst), scale.Format(s.Worst.Rating))
+	}
+	return line
+}
+
+// ValidateArcs checks arcs for duplicate IDs, empty names, unknown works,
+// reversed ranges and arcs of a work that overlap.
+func ValidateArcs(arcs []models.Arc, books []models.Book, works []models.Work) error {
+	var errs []error
+	seen := make(map[uint32]bool, len(arcs))
+	for i, a := range arcs {
+		if seen[a.ID] {
+			errs = append(errs, fmt.Errorf("arc #%d: duplicate id %d", i+1, a.ID))
+		}
+		seen[a.ID] = true
+		if strings.TrimSpace(a.Name) == "" {
+			errs = append(errs, fmt.Errorf("arc #%d (id %d): empty name", i+1, a.ID))
+		}
+		switch ref := ArcWork(a); {
+		case ref.BookID != 0 && ref.WorkID != 0:
+			errs = append(errs, fmt.Errorf("arc #%d (id %d): names both book %d and work %d", i+1, a.ID, ref.BookID, ref.WorkID))
+		case ref == WorkRef{}:
+			errs = append(errs, fmt.Errorf("arc #%d (id %d): names no work", i+1, a.ID))
+		case WorkTitle(books, works, ref) == "":
+			errs = append(errs, fmt.Errorf("arc #%d (id %d): %s", i+1, a.ID, WorkLabel(books, works, ref)))
+		}
+		for _, p := range []models.ChapterPoint{a.First, a.Last} {
+			if err := validateChapter(pointReview(p)); err != nil {
+				errs = append(errs, fmt.Errorf("arc #%d (id %d): %w", i+1, a.ID, err))
+			}
+		}
+		if ComparePoints(a.First, a.Last) > 0 {
+			errs = append(errs, fmt.Errorf("arc #%d (id %d): ends at %s before it starts at %s",
+				i+1, a.ID, FormatPoint(a.Last), FormatPoint(a.First)))
+		}
+		if other, ok := ArcOverlap(arcs[:i], a); ok {
+			errs = append(errs, fmt.Errorf("arc #%d (id %d): %s overlaps %s", i+1, a.ID, a.Name, other.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ArcLabel describes an arc in pickers.
+func ArcLabel(books []models.Book, works []models.Work, a models.Arc) string {
+	return joinLabel(WorkLabel(books, works, ArcWork(a)), a.Name, ArcRange(a))
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"utilodactyl/models"
+)
+
+// bulkFile keeps the bulk review entry in progress, so an interrupted entry
+// can be resumed.
+const bulkFile = "reviews.pending.json"
+
+// LoadBulkSession returns the bulk review entry in progress, or nil if there
+// is none.
+func LoadBulkSession() (*models.BulkSession, error) {
+	data, err := os.ReadFile(bulkFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", bulkFile, err)
+	}
+	var session models.BulkSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", bulkFile, err)
+	}
+	return &session, nil
+}
+
+func SaveBulkSession(session *models.BulkSession) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+	if err := os.WriteFile(bulkFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", bulkFile, err)
+	}
+	return nil
+}
+
+// ClearBulkSession forgets the bulk review entry in progress.
+func ClearBulkSession() error {
+	if err := os.Remove(bulkFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", bulkFile, err)
+	}
+	return nil
+}
+
+// ChapterAfter returns the chapter a walk through a range asks about after
+// entered, when it asked about next: the whole number after a numbered
+// chapter. Special chapters leave the numbering where it was.
+func ChapterAfter(next, entered models.ChapterPoint) models.ChapterPoint {
+	if entered.Label != "" {
+		return next
+	}
+	return models.ChapterPoint{Volume: entered.Volume, Chapter: math.Floor(entered.Chapter) + 1}
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"utilodactyl/models"
+)
+
+// ChapterLabels lists the special chapter labels in the order they sort
+// within a volume, around the numbered chapters.
+var ChapterLabels = []models.ChapterLabel{models.LabelPrologue, models.LabelEpilogue, models.LabelExtra}
+
+// chapterRank places a label relative to the numbered chapters of a volume.
+func chapterRank(label models.ChapterLabel) int {
+	switch label {
+	case models.LabelPrologue:
+		return 0
+	case "":
+		return 1
+	case models.LabelEpilogue:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// CompareChapters orders reviews of the same work by volume, then prologues,
+// numbered chapters, epilogues and extras, each by number.
+func CompareChapters(a, b models.Review) int {
+	return cmp.Or(
+		cmp.Compare(a.Volume, b.Volume),
+		cmp.Compare(chapterRank(a.Label), chapterRank(b.Label)),
+		cmp.Compare(a.Chapter, b.Chapter),
+	)
+}
+
+// SameChapter reports whether two reviews are for the same chapter of a work,
+// given they are for the same work.
+func SameChapter(a, b models.Review) bool {
+	return a.Volume == b.Volume && a.Label == b.Label && a.Chapter == b.Chapter
+}
+
+// ParseChapter reads a chapter as typed: a number such as "10" or "10.5", or a
+// special label with an optional number, such as "Prologue" or "Extra 2".
+func ParseChapter(text string) (models.ChapterLabel, float64, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", 0, fmt.Errorf("chapter cannot be empty")
+	}
+	if n, err := parseChapterNumber(text); err == nil {
+		if n == 0 {
+			return "", 0, fmt.Errorf("chapter number must be greater than 0")
+		}
+		return "", n, nil
+	}
+
+	word, rest, _ := strings.Cut(text, " ")
+	i := slices.IndexFunc(ChapterLabels, func(l models.ChapterLabel) bool {
+		return strings.EqualFold(string(l), word)
+	})
+	if i < 0 {
+		return "", 0, fmt.Errorf("enter a number such as 10 or 10.5, or one of %s", labelList())
+	}
+	if rest = strings.TrimSpace(rest); rest == "" {
+		return ChapterLabels[i], 0, nil
+	}
+	n, err := parseChapterNumber(rest)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid number after %s: %w", ChapterLabels[i], err)
+	}
+	return ChapterLabels[i], n, nil
+}
+
+func parseChapterNumber(text string) (float64, error) {
+	n, err := strconv.ParseFloat(text, 64)
+	if err != nil || n < 0 || math.IsInf(n, 0) || math.IsNaN(n) {
+		return 0, fmt.Errorf("not a chapter number")
+	}
+	return n, nil
+}
+
+func labelList() string {
+	names := make([]string, len(ChapterLabels))
+	for i, l := range ChapterLabels {
+		names[i] = string(l)
+	}
+	return strings.Join(names, ", ")
+}
+
+// ChapterText renders a review's chapter the way ParseChapter reads it, e.g.
+// "10.5" or "Extra 2".
+func ChapterText(r models.Review) string {
+	number := strconv.FormatFloat(r.Chapter, 'f', -1, 64)
+	switch {
+	case r.Label == "":
+		return number
+	case r.Chapter == 0:
+		return string(r.Label)
+	default:
+		return string(r.Label) + " " + number
+	}
+}
+
+// FormatChapter renders a review's chapter for display, e.g. "Ch. 10.5",
+// "Vol. 2, Ch. 3" or "Vol. 2, Extra".
+func FormatChapter(r models.Review) string {
+	chapter := ChapterText(r)
+	if r.Label == "" {
+		chapter = "Ch. " + chapter
+	}
+	if r.Volume != 0 {
+		return fmt.Sprintf("Vol. %d, %s", r.Volume, chapter)
+	}
+	return chapter
+}
+
+func validateChapter(r models.Review) error {
+	if r.Label != "" && !slices.Contains(ChapterLabels, r.Label) {
+		return fmt.Errorf("unknown chapter label %q", r.Label)
+	}
+	if r.Chapter < 0 || r.Label == "" && r.Chapter == 0 {
+		return fmt.Errorf("invalid chapter number %s", ChapterText(r))
+	}
+	return nil
+}
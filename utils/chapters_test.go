@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"utilodactyl/models"
+)
+
+func chapter(volume uint32, label models.ChapterLabel, n float64) models.Review {
+	return models.Review{Volume: volume, Label: label, Chapter: n}
+}
+
+// TestCompareChaptersSortOrder sorts a shuffled work and checks it comes out
+// in reading order.
+func TestCompareChaptersSortOrder(t *testing.T) {
+	want := []models.Review{
+		chapter(0, models.LabelPrologue, 0),
+		chapter(0, "", 1),
+		chapter(0, "", 2),
+		chapter(0, "", 2.5),
+		chapter(0, "", 10),
+		chapter(0, models.LabelEpilogue, 0),
+		chapter(0, models.LabelExtra, 1),
+		chapter(0, models.LabelExtra, 2),
+		chapter(1, models.LabelPrologue, 0),
+		chapter(1, "", 1),
+		chapter(1, models.LabelEpilogue, 0),
+		chapter(2, "", 1),
+		chapter(2, models.LabelExtra, 0),
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for range 20 {
+		got := slices.Clone(want)
+		rng.Shuffle(len(got), func(i, j int) { got[i], got[j] = got[j], got[i] })
+		slices.SortFunc(got, CompareChapters)
+		if !slices.Equal(got, want) {
+			names := make([]string, len(got))
+			for i, r := range got {
+				names[i] = FormatChapter(r)
+			}
+			t.Fatalf("sorted into %v", names)
+		}
+	}
+}
+
+func TestCompareChapters(t *testing.T) {
+	tests := []struct {
+		a, b models.Review
+		want int
+	}{
+		{chapter(0, "", 1), chapter(0, "", 1), 0},
+		{chapter(0, "", 1), chapter(0, "", 2), -1},
+		{chapter(0, "", 10), chapter(0, "", 9.5), 1},
+		// Volumes come before everything else.
+		{chapter(1, "", 99), chapter(2, models.LabelPrologue, 0), -1},
+		// Prologues open a volume, epilogues and extras close it.
+		{chapter(0, models.LabelPrologue, 0), chapter(0, "", 1), -1},
+		{chapter(0, models.LabelEpilogue, 0), chapter(0, "", 100), 1},
+		{chapter(0, models.LabelExtra, 0), chapter(0, models.LabelEpilogue, 3), 1},
+		{chapter(0, models.LabelExtra, 1), chapter(0, models.LabelExtra, 2), -1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareChapters(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareChapters(%s, %s) = %d, want %d", FormatChapter(tt.a), FormatChapter(tt.b), got, tt.want)
+		}
+	}
+}
+
+func TestChapterAfter(t *testing.T) {
+	point := func(volume uint32, label models.ChapterLabel, n float64) models.ChapterPoint {
+		return models.ChapterPoint{Volume: volume, Label: label, Chapter: n}
+	}
+	tests := []struct {
+		name          string
+		next, entered models.ChapterPoint
+		want          models.ChapterPoint
+	}{
+		{"the chapter asked about", point(0, "", 3), point(0, "", 3), point(0, "", 4)},
+		{"a half chapter", point(0, "", 3), point(0, "", 3.5), point(0, "", 4)},
+		{"a chapter further on", point(0, "", 3), point(0, "", 7), point(0, "", 8)},
+		{"another volume", point(1, "", 12), point(2, "", 1), point(2, "", 2)},
+		{"a prologue", point(0, "", 3), point(0, models.LabelPrologue, 0), point(0, "", 3)},
+		{"an extra", point(2, "", 5), point(2, models.LabelExtra, 1), point(2, "", 5)},
+	}
+
+	for _, tt := range tests {
+		if got := ChapterAfter(tt.next, tt.entered); got != tt.want {
+			t.Errorf("%s: ChapterAfter(%s, %s) = %s, want %s", tt.name,
+				FormatPoint(tt.next), FormatPoint(tt.entered), FormatPoint(got), FormatPoint(tt.want))
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"utilodactyl/models"
+)
+
+const ConfigFile = "utilodactyl.json"
+
+// LoadConfig reads the settings file. A missing file gives the defaults.
+func LoadConfig() (models.Config, error) {
+	var cfg models.Config
+	data, err := os.ReadFile(ConfigFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("failed to read %s: %w", ConfigFile, err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal %s: %w", ConfigFile, err)
+	}
+	return cfg, nil
+}
+
+// SaveConfig writes the settings file.
+func SaveConfig(cfg models.Config) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.WriteFile(ConfigFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ConfigFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// EntryChange describes how one entry of a collection differs between two
+// versions of its JSON file.
+type EntryChange struct {
+	Kind   string   // "added", "removed" or "changed".
+	Label  string   // A human readable name for the entry.
+	Fields []string // For changed entries, one "field: old → new" line per field.
+}
+
+func (c EntryChange) String() string {
+	switch c.Kind {
+	case "added":
+		return "+ " + c.Label
+	case "removed":
+		return "- " + c.Label
+	default:
+		return fmt.Sprintf("~ %s\n    %s", c.Label, strings.Join(c.Fields, "\n    "))
+	}
+}
+
+// DiffJSON compares two JSON arrays of objects, matching entries on keyField
+// and labelling them with labelField.
+func DiffJSON(before, after []byte, keyField, labelField string) ([]EntryChange, error) {
+	oldEntries, err := decodeEntries(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode previous version: %w", err)
+	}
+	newEntries, err := decodeEntries(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode new version: %w", err)
+	}
+
+	oldByKey := make(map[string]map[string]any, len(oldEntries))
+	for _, e := range oldEntries {
+		oldByKey[fmt.Sprint(e[keyField])] = e
+	}
+
+	var changes []EntryChange
+	seen := make(map[string]bool, len(newEntries))
+	for _, e := range newEntries {
+		key := fmt.Sprint(e[keyField])
+		seen[key] = true
+		label := entryLabel(e, keyField, labelField)
+
+		old, ok := oldByKey[key]
+		if !ok {
+			changes = append(changes, EntryChange{Kind: "added", Label: label})
+			continue
+		}
+		if fields := diffFields(old, e); len(fields) > 0 {
+			changes = append(changes, EntryChange{Kind: "changed", Label: label, Fields: fields})
+		}
+	}
+
+	for _, e := range oldEntries {
+		if !seen[fmt.Sprint(e[keyField])] {
+			changes = append(changes, EntryChange{Kind: "removed", Label: entryLabel(e, keyField, labelField)})
+		}
+	}
+
+	return changes, nil
+}
+
+func decodeEntries(data []byte) ([]map[string]any, error) {
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, nil
+	}
+	var entries []map[string]any
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func entryLabel(e map[string]any, keyField, labelField string) string {
+	if label, ok := e[labelField].(string); ok && label != "" && labelField != keyField {
+		return fmt.Sprintf("%s (%s %v)", label, keyField, e[keyField])
+	}
+	return fmt.Sprintf("%s %v", keyField, e[keyField])
+}
+
+func diffFields(old, new map[string]any) []string {
+	names := make(map[string]struct{}, len(old)+len(new))
+	for k := range old {
+		names[k] = struct{}{}
+	}
+	for k := range new {
+		names[k] = struct{}{}
+	}
+
+	keys := make([]string, 0, len(names))
+	for k := range names {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var fields []string
+	for _, k := range keys {
+		if !reflect.DeepEqual(old[k], new[k]) {
+			fields = append(fields, fmt.Sprintf("%s: %s → %s", k, formatValue(old[k]), formatValue(new[k])))
+		}
+	}
+	return fields
+}
+
+func formatValue(v any) string {
+	if v == nil {
+		return "(none)"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if r := []rune(string(data)); len(r) > 60 {
+		return string(r[:57]) + "..."
+	}
+	return string(data)
+}
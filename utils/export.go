@@ -0,0 +1,410 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+	"utilodactyl/models"
+)
+
+// exportDir holds the generated versions of collection files that differ from
+// the local ones, such as books.json with parent genres expanded, without
+// timestamps or without private, draft and scheduled entries.
+const exportDir = ".publish"
+
+// exporters validate a collection and build its public form: private, draft
+// and not yet due entries and private fields are left out, and values the
+// site works out from others are added. Collections without an exporter are
+// published as the local file.
+var exporters = map[string]func() (any, error){
+	booksFile:    exportBooks,
+	gamesFile:    exportGames,
+	projectsFile: exportProjects,
+	reviewsFile:  exportReviews,
+	ArcsFile:     exportArcs,
+}
+
+// openAsset opens the file to publish as fileName: the generated version when
+// the collection has one, otherwise the local file itself.
+func openAsset(fileName string) (*os.File, error) {
+	if export, ok := exporters[fileName]; ok {
+		data, err := export()
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", fileName, err)
+		}
+		return writeExport(fileName, data)
+	}
+
+	file, err := os.Open("./" + fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open local %s: %w", fileName, err)
+	}
+	return file, nil
+}
+
+func writeExport(fileName string, data any) (*os.File, error) {
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", fileName, err)
+	}
+	if err := os.MkdirAll(exportDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", exportDir, err)
+	}
+	path := filepath.Join(exportDir, fileName)
+	if err := os.WriteFile(path, encoded, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return os.Open(path)
+}
+
+// normalizedRating maps a rating onto 0–1 for the site, so it can compare
+// ratings whatever scale each collection uses. Unset ratings are left out.
+func normalizedRating(scale Scale, r models.Rating) *float64 {
+	if r == 0 {
+		return nil
+	}
+	n := scale.Normalize(r)
+	return &n
+}
+
+// publishedBook is a book as published, with its progress worked out for the
+// site's progress bars, the name of its series and its contributors by name.
+// The author stays a flat string, as the site has always read it.
+type publishedBook struct {
+	models.Book
+	RatingNormalized *float64 `json:"ratingNormalized,omitempty"`
+
+	Percent      *int                   `json:"percent,omitempty"`
+	Series       string                 `json:"series,omitempty"`
+	Contributors []publishedContributor `json:"contributors,omitempty"`
+}
+
+type publishedContributor struct {
+	Name string      `json:"name"`
+	Role models.Role `json:"role"`
+}
+
+func exportBooks() (any, error) {
+	books, err := LoadBooks()
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateBooks(books); err != nil {
+		return nil, err
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	scale, err := FindScale(cfg.Books.RatingScale)
+	if err != nil {
+		return nil, err
+	}
+	vocab, err := LoadVocabulary(BookVocabularyFile)
+	if err != nil {
+		return nil, err
+	}
+	series, err := LoadSeries()
+	if err != nil {
+		return nil, err
+	}
+	people, err := LoadPeople()
+	if err != nil {
+		return nil, err
+	}
+
+	taxonomy, err := BookWarnings()
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := ExcludedBookIDs(books, cfg.Books.MaxWarning, time.Now())
+	published := make([]json.RawMessage, 0, len(books))
+	for _, b := range books {
+		if excluded[b.ID] {
+			continue
+		}
+		b.Explicit = DeriveExplicit(taxonomy, b.ContentWarnings, b.Explicit)
+		if vocab.ExpandParents {
+			b.Genres = ExpandParents(vocab.Genres, b.Genres)
+			b.Tags = ExpandParents(vocab.Tags, b.Tags)
+		}
+		if !cfg.Books.ExportTimestamps {
+			b.AddedAt, b.UpdatedAt, b.FinishedAt, b.StatusHistory = nil, nil, nil, nil
+		}
+		// With its contributors private, the author line is published as saved
+		// instead of being rebuilt from them.
+		if !Unpublished(cfg.Books.PrivateFields, b.PrivateFields)["contributors"] {
+			if line := AuthorLine(people, b.Contributors); line != "" {
+				b.Author = line
+			}
+		}
+		p := publishedBook{Book: b, RatingNormalized: normalizedRating(scale, b.Rating)}
+		for _, c := range b.Contributors {
+			p.Contributors = append(p.Contributors,
+				publishedContributor{Name: PersonName(people, c.PersonID), Role: c.Role})
+		}
+		if s, ok := FindSeries(series, b.SeriesID); ok {
+			p.Series = s.Name
+		}
+		if percent, ok := BookPercent(b); ok {
+			p.Percent = &percent
+		}
+		entry, err := omitKeys(p, privateKeys(BookPrivateFields, cfg.Books.PrivateFields, b.PrivateFields))
+		if err != nil {
+			return nil, err
+		}
+		published = append(published, entry)
+	}
+	return published, nil
+}
+
+// publishedGame is a game as published, with the totals worked out from its
+// play sessions.
+type publishedGame struct {
+	models.Game
+	RatingNormalized *float64 `json:"ratingNormalized,omitempty"`
+	PlaytimeMinutes  uint32   `json:"playtimeMinutes,omitempty"`
+	LastPlayed       string   `json:"lastPlayed,omitempty"`
+}
+
+func exportGames() (any, error) {
+	games, err := LoadGames()
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateGames(games); err != nil {
+		return nil, err
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	scale, err := FindScale(cfg.Games.RatingScale)
+	if err != nil {
+		return nil, err
+	}
+	vocab, err := LoadVocabulary(GameVocabularyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	taxonomy, err := GameWarnings()
+	if err != nil {
+		return nil, err
+	}
+
+	at := time.Now()
+	published := make([]json.RawMessage, 0, len(games))
+	for _, g := range games {
+		if GameUnlisted(g, at) || ExceedsWarning(g.ContentWarnings, cfg.Games.MaxWarning) {
+			continue
+		}
+		g.Explicit = DeriveExplicit(taxonomy, g.ContentWarnings, g.Explicit)
+		if vocab.ExpandParents {
+			g.Genres = ExpandParents(vocab.Genres, g.Genres)
+			g.Tags = ExpandParents(vocab.Tags, g.Tags)
+		}
+		if !cfg.Games.ExportTimestamps {
+			g.AddedAt, g.UpdatedAt, g.FinishedAt, g.StatusHistory = nil, nil, nil, nil
+		}
+		p := publishedGame{
+			Game:             g,
+			RatingNormalized: normalizedRating(scale, g.Rating),
+			PlaytimeMinutes:  Playtime(g),
+			LastPlayed:       LastPlayed(g),
+		}
+		entry, err := omitKeys(p, privateKeys(GamePrivateFields, cfg.Games.PrivateFields, g.PrivateFields))
+		if err != nil {
+			return nil, err
+		}
+		published = append(published, entry)
+	}
+	return published, nil
+}
+
+func exportProjects() (any, error) {
+	projects, err := LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateProjects(projects); err != nil {
+		return nil, err
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	at := time.Now()
+	published := make([]json.RawMessage, 0, len(projects))
+	for _, p := range projects {
+		if ProjectUnlisted(p, at) {
+			continue
+		}
+		entry, err := omitKeys(p, privateKeys(ProjectPrivateFields, cfg.Projects.PrivateFields, p.PrivateFields))
+		if err != nil {
+			return nil, err
+		}
+		published = append(published, entry)
+	}
+	return published, nil
+}
+
+// publishedReview is a review as published, naming the work it is for and
+// the arc it falls in.
+type publishedReview struct {
+	models.Review
+	RatingNormalized *float64 `json:"ratingNormalized,omitempty"`
+	Work             string   `json:"work,omitempty"`
+	Arc              string   `json:"arc,omitempty"`
+}
+
+func exportReviews() (any, error) {
+	reviews, err := LoadReviews()
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateReviews(reviews); err != nil {
+		return nil, err
+	}
+
+	books, err := LoadBooks()
+	if err != nil {
+		return nil, err
+	}
+	works, err := LoadWorks()
+	if err != nil {
+		return nil, err
+	}
+	arcs, err := LoadArcs()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	scale, err := FindScale(cfg.Reviews.RatingScale)
+	if err != nil {
+		return nil, err
+	}
+
+	at := time.Now()
+	excluded := ExcludedBookIDs(books, cfg.Books.MaxWarning, at)
+	keys := privateKeys(ReviewPrivateFields, cfg.Reviews.PrivateFields, nil)
+	reviews = GroupReviewsByWork(reviews, books, works)
+	published := make([]json.RawMessage, 0, len(reviews))
+	for _, r := range reviews {
+		if excluded[r.BookID] || ReviewUnlisted(r, at) {
+			continue
+		}
+		p := publishedReview{
+			Review:           r,
+			RatingNormalized: normalizedRating(scale, r.Rating),
+			Work:             WorkTitle(books, works, ReviewWork(r)),
+		}
+		if a, ok := FindArc(arcs, r); ok {
+			p.Arc = a.Name
+		}
+		entry, err := omitKeys(p, keys)
+		if err != nil {
+			return nil, err
+		}
+		published = append(published, entry)
+	}
+	return published, nil
+}
+
+// publishedArc is a story arc as published for the site's arc overview, with
+// the statistics of its reviews. The statistics are left out while the arc
+// has no reviews, and all but the count while review ratings are private.
+type publishedArc struct {
+	models.Arc
+	Work    string   `json:"work"`
+	Range   string   `json:"range"`
+	Count   int      `json:"count"`
+	Average *float64 `json:"average,omitempty"`
+	// AverageNormalized is the average mapped onto 0–1, like ratingNormalized.
+	AverageNormalized *float64          `json:"averageNormalized,omitempty"`
+	Trend             *float64          `json:"trend,omitempty"`
+	Best              *publishedChapter `json:"best,omitempty"`
+	Worst             *publishedChapter `json:"worst,omitempty"`
+}
+
+// publishedChapter points at the review of a chapter.
+type publishedChapter struct {
+	ReviewID         uint32        `json:"reviewId"`
+	Chapter          string        `json:"chapter"`
+	Rating           models.Rating `json:"rating"`
+	RatingNormalized *float64      `json:"ratingNormalized,omitempty"`
+}
+
+func chapterOf(scale Scale, r models.Review) *publishedChapter {
+	return &publishedChapter{ReviewID: r.ID, Chapter: FormatChapter(r), Rating: r.Rating, RatingNormalized: normalizedRating(scale, r.Rating)}
+}
+
+func exportArcs() (any, error) {
+	reviews, err := LoadReviews()
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateReviews(reviews); err != nil {
+		return nil, err
+	}
+
+	books, err := LoadBooks()
+	if err != nil {
+		return nil, err
+	}
+	works, err := LoadWorks()
+	if err != nil {
+		return nil, err
+	}
+	arcs, err := LoadArcs()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	scale, err := FindScale(cfg.Reviews.RatingScale)
+	if err != nil {
+		return nil, err
+	}
+	// Ratings kept private from the reviews stay out of the arc statistics.
+	hideRatings := slices.Contains(cfg.Reviews.PrivateFields, "rating")
+
+	at := time.Now()
+	excluded := ExcludedBookIDs(books, cfg.Books.MaxWarning, at)
+	// Draft and scheduled reviews stay out of the statistics until they are
+	// published.
+	reviews = slices.DeleteFunc(reviews, func(r models.Review) bool { return ReviewUnlisted(r, at) })
+	arcs = GroupArcsByWork(arcs, books, works)
+	published := make([]publishedArc, 0, len(arcs))
+	for _, a := range arcs {
+		if excluded[a.BookID] {
+			continue
+		}
+		p := publishedArc{Arc: a, Work: WorkTitle(books, works, ArcWork(a)), Range: ArcRange(a)}
+		if stats, ok := ComputeArcStats(a, reviews); ok {
+			p.Count = stats.Count
+			if !hideRatings {
+				average, trend := math.Round(stats.Average*100)/100, math.Round(stats.Trend*100)/100
+				p.Average, p.Trend = &average, &trend
+				p.AverageNormalized = normalizedRating(scale, models.Rating(stats.Average))
+				p.Best, p.Worst = chapterOf(scale, stats.Best), chapterOf(scale, stats.Worst)
+			}
+		}
+		published = append(published, p)
+	}
+	return published, nil
+}
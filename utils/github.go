@@ -0,0 +1,295 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+	"utilodactyl/models"
+
+	"github.com/google/go-github/github"
+	"github.com/joho/godotenv"
+	"golang.org/x/oauth2"
+)
+
+const (
+	releaseOwner = "TheBearodactyl"
+	releaseRepo  = "bearodactyl.dev"
+	releaseTag   = "v1.0.0"
+)
+
+// publicRelease is the release the site reads the collections from.
+var publicRelease = models.ReleaseConfig{Owner: releaseOwner, Repo: releaseRepo, Tag: releaseTag}
+
+// privateRelease returns the release configured for the full collections, if
+// any.
+func privateRelease() (*models.ReleaseConfig, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	rel := cfg.PrivateRelease
+	if rel != nil && (rel.Owner == "" || rel.Repo == "" || rel.Tag == "") {
+		return nil, fmt.Errorf("privateRelease in %s needs an owner, a repo and a tag", ConfigFile)
+	}
+	if rel != nil && *rel == publicRelease {
+		return nil, fmt.Errorf("privateRelease in %s is the public release", ConfigFile)
+	}
+	return rel, nil
+}
+
+// NewGitHubClient builds an authenticated GitHub client from the environment.
+// GITHUB_TOKEN is required. GITHUB_API_URL and GITHUB_UPLOAD_URL point the
+// client at GitHub Enterprise Server or another compatible API; when only the
+// API URL is set, the upload URL is derived from it.
+func NewGitHubClient(ctx context.Context) (*github.Client, error) {
+	if err := godotenv.Load(); err != nil {
+		if os.IsNotExist(err) {
+			if models.Cli.Verbose {
+				fmt.Println(".env file not found. Falling back to system environment variables.")
+			}
+		} else {
+			return nil, fmt.Errorf("error loading .env file: %w", err)
+		}
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("missing GITHUB_TOKEN environment variable")
+	}
+
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	)
+	tc := oauth2.NewClient(ctx, ts)
+
+	baseURL := strings.TrimSpace(os.Getenv("GITHUB_API_URL"))
+	uploadURL := strings.TrimSpace(os.Getenv("GITHUB_UPLOAD_URL"))
+	if baseURL == "" && uploadURL == "" {
+		return github.NewClient(tc), nil
+	}
+	if baseURL == "" {
+		return nil, fmt.Errorf("GITHUB_UPLOAD_URL is set but GITHUB_API_URL is not")
+	}
+	if uploadURL == "" {
+		uploadURL = deriveUploadURL(baseURL)
+	}
+
+	client, err := github.NewEnterpriseClient(baseURL, uploadURL, tc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub API URL: %w", err)
+	}
+	if models.Cli.Verbose {
+		fmt.Printf("Using GitHub API at %s (uploads: %s)\n", client.BaseURL, client.UploadURL)
+	}
+	return client, nil
+}
+
+// deriveUploadURL maps a GitHub Enterprise Server API URL (".../api/v3") to its
+// upload endpoint (".../api/uploads"). Any other URL is assumed to serve
+// uploads itself.
+func deriveUploadURL(baseURL string) string {
+	trimmed := strings.TrimSuffix(baseURL, "/")
+	if strings.HasSuffix(trimmed, "/api/v3") {
+		return strings.TrimSuffix(trimmed, "/v3") + "/uploads/"
+	}
+	return baseURL
+}
+
+// PullReleaseAsset downloads fileName from the release and writes it to the
+// working directory under the same name. Collections published through an
+// exporter are only pulled from the private release: their public asset
+// lacks the private data and carries export-only keys, so writing it over the
+// local file would lose entries.
+func PullReleaseAsset(fileName string) error {
+	rel, err := privateRelease()
+	if err != nil {
+		return err
+	}
+	if rel == nil {
+		if _, ok := exporters[fileName]; ok {
+			return fmt.Errorf("refusing to pull %s from the public release, which only holds its published form; set privateRelease in %s to pull the full file", fileName, ConfigFile)
+		}
+		rel = &publicRelease
+	}
+
+	ctx := context.Background()
+	client, err := NewGitHubClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	release, _, err := client.Repositories.GetReleaseByTag(ctx, rel.Owner, rel.Repo, rel.Tag)
+	if err != nil {
+		return fmt.Errorf("failed to get release by tag %s: %w", rel.Tag, err)
+	}
+
+	var assetID int64
+	for _, asset := range release.Assets {
+		if asset.GetName() == fileName {
+			assetID = asset.GetID()
+			break
+		}
+	}
+
+	if assetID == 0 {
+		return fmt.Errorf("asset %s not found in release %s", fileName, rel.Tag)
+	}
+
+	rc, url, err := client.Repositories.DownloadReleaseAsset(ctx, rel.Owner, rel.Repo, assetID)
+	if err != nil {
+		return fmt.Errorf("failed to download asset: %w", err)
+	}
+	defer func() {
+		if rc != nil {
+			rc.Close()
+		}
+	}()
+
+	var data io.ReadCloser
+	if rc != nil {
+		data = rc
+	} else {
+		resp, err := http.Get(url)
+		if err != nil {
+			return fmt.Errorf("failed to fetch asset from redirect URL: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to fetch asset from redirect URL: %s", resp.Status)
+		}
+		data = resp.Body
+	}
+
+	out, err := os.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", fileName, err)
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, data)
+	if err != nil {
+		return fmt.Errorf("failed to write asset to file: %w", err)
+	}
+
+	fmt.Printf("Downloaded %s successfully\n", fileName)
+	return nil
+}
+
+// PublishCollection replaces the release asset named fileName with the
+// public form of the collection, which may differ from the local file (see
+// exporters). When a private release is configured, the local file is
+// uploaded there as it is.
+func PublishCollection(fileName string) error {
+	rel, err := privateRelease()
+	if err != nil {
+		return err
+	}
+
+	// Export first, so a failed export never leaves the release without the
+	// asset.
+	started := time.Now()
+	file, err := openAsset(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := uploadAsset(publicRelease, fileName, file); err != nil {
+		return err
+	}
+	if err := recordPublished(fileName, started); err != nil {
+		return err
+	}
+
+	if rel == nil {
+		return nil
+	}
+	local, err := os.Open("./" + fileName)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open local %s: %w", fileName, err)
+	}
+	defer local.Close()
+	if err := uploadAsset(*rel, fileName, local); err != nil {
+		return fmt.Errorf("failed to upload the private %s: %w", fileName, err)
+	}
+	return nil
+}
+
+// releaseError is a failed request to the release API, such as a network
+// error or an error response. Unlike a missing token, a missing file or a
+// failed export, it may go away on its own, so it is worth retrying later.
+type releaseError struct {
+	err error
+}
+
+func (e *releaseError) Error() string { return e.err.Error() }
+func (e *releaseError) Unwrap() error { return e.err }
+
+// retryable reports whether err came from the release API rather than from
+// something that has to be fixed first. Client errors such as a missing
+// release or a rejected upload fail the same way every time, except for
+// timeouts and rate limits.
+func retryable(err error) bool {
+	var rerr *releaseError
+	if !errors.As(err, &rerr) {
+		return false
+	}
+	var resp *github.ErrorResponse
+	if errors.As(err, &resp) && resp.Response != nil {
+		code := resp.Response.StatusCode
+		return code < 400 || code >= 500 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+	}
+	return true
+}
+
+func uploadAsset(rel models.ReleaseConfig, fileName string, file *os.File) error {
+	ctx := context.Background()
+	client, err := NewGitHubClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	release, _, err := client.Repositories.GetReleaseByTag(ctx, rel.Owner, rel.Repo, rel.Tag)
+	if err != nil {
+		return &releaseError{fmt.Errorf("error getting release by tag %s: %w", rel.Tag, err)}
+	}
+
+	for _, asset := range release.Assets {
+		if asset.GetName() == fileName {
+			if models.Cli.Verbose {
+				fmt.Printf("Found existing asset '%s' with ID %d. Deleting...\n", fileName, asset.GetID())
+			}
+			_, err := client.Repositories.DeleteReleaseAsset(ctx, rel.Owner, rel.Repo, asset.GetID())
+			if err != nil {
+				return &releaseError{fmt.Errorf("error deleting existing asset %s (ID: %d): %w", fileName, asset.GetID(), err)}
+			}
+			if models.Cli.Verbose {
+				fmt.Println("Asset deleted successfully.")
+			}
+			break
+		}
+	}
+
+	if models.Cli.Verbose {
+		fmt.Printf("Uploading new asset '%s' to release ID %d...\n", fileName, release.GetID())
+	}
+	_, _, err = client.Repositories.UploadReleaseAsset(ctx, rel.Owner, rel.Repo, release.GetID(), &github.UploadOptions{
+		Name: fileName,
+	}, file)
+	if err != nil {
+		return &releaseError{fmt.Errorf("error uploading asset: %w", err)}
+	}
+
+	if models.Cli.Verbose {
+		fmt.Printf("Upload of '%s' successful to release ID %d.\n", fileName, release.GetID())
+	}
+
+	return nil
+}
@@ -0,0 +1,701 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+	"utilodactyl/models"
+)
+
+// publicReleaseID is the ID the fake server gives the public release.
+const publicReleaseID = 1
+
+type fakeAsset struct {
+	release int64
+	name    string
+	content []byte
+}
+
+// fakeReleaseServer mimics the subset of the GitHub Enterprise release API used
+// by the pull and update actions. API calls are served under /api/v3 and
+// uploads under /api/uploads, like a GitHub Enterprise Server instance. It
+// serves the public release, and any other release added with addRelease.
+type fakeReleaseServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	releases map[models.ReleaseConfig]int64
+	assets   map[int64]*fakeAsset
+	nextID   int64
+	redirect bool
+}
+
+func newFakeReleaseServer(t *testing.T) *fakeReleaseServer {
+	t.Helper()
+
+	f := &fakeReleaseServer{
+		releases: map[models.ReleaseConfig]int64{publicRelease: publicReleaseID},
+		assets:   map[int64]*fakeAsset{},
+		nextID:   100,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/releases/tags/{tag}", f.getRelease)
+	mux.HandleFunc("GET /api/v3/repos/{owner}/{repo}/releases/assets/{id}", f.downloadAsset)
+	mux.HandleFunc("DELETE /api/v3/repos/{owner}/{repo}/releases/assets/{id}", f.deleteAsset)
+	mux.HandleFunc("POST /api/uploads/repos/{owner}/{repo}/releases/{release}/assets", f.uploadAsset)
+	mux.HandleFunc("GET /storage/{id}", f.serveStorage)
+
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_API_URL", f.URL+"/api/v3")
+	t.Setenv("GITHUB_UPLOAD_URL", "")
+
+	return f
+}
+
+// addRelease serves rel alongside the public release and returns its ID.
+func (f *fakeReleaseServer) addRelease(rel models.ReleaseConfig) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	f.releases[rel] = f.nextID
+	return f.nextID
+}
+
+func (f *fakeReleaseServer) addAsset(name string, content []byte) int64 {
+	return f.addReleaseAsset(publicReleaseID, name, content)
+}
+
+func (f *fakeReleaseServer) addReleaseAsset(release int64, name string, content []byte) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	f.assets[f.nextID] = &fakeAsset{release: release, name: name, content: content}
+	return f.nextID
+}
+
+func (f *fakeReleaseServer) assetByName(name string) ([]byte, int) {
+	return f.releaseAssetByName(publicReleaseID, name)
+}
+
+func (f *fakeReleaseServer) releaseAssetByName(release int64, name string) ([]byte, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var content []byte
+	count := 0
+	for _, a := range f.assets {
+		if a.release == release && a.name == name {
+			content = a.content
+			count++
+		}
+	}
+	return content, count
+}
+
+func (f *fakeReleaseServer) assetID(r *http.Request) (int64, bool) {
+	var id int64
+	if err := json.Unmarshal([]byte(r.PathValue("id")), &id); err != nil {
+		return 0, false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.assets[id]
+	return id, ok
+}
+
+func (f *fakeReleaseServer) getRelease(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+		return
+	}
+
+	rel := models.ReleaseConfig{Owner: r.PathValue("owner"), Repo: r.PathValue("repo"), Tag: r.PathValue("tag")}
+	f.mu.Lock()
+	release, ok := f.releases[rel]
+	assets := make([]map[string]any, 0, len(f.assets))
+	for id, a := range f.assets {
+		if a.release == release {
+			assets = append(assets, map[string]any{"id": id, "name": a.name})
+		}
+	}
+	f.mu.Unlock()
+	if !ok {
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"id":       release,
+		"tag_name": rel.Tag,
+		"assets":   assets,
+	})
+}
+
+func (f *fakeReleaseServer) downloadAsset(w http.ResponseWriter, r *http.Request) {
+	id, ok := f.assetID(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Header.Get("Accept") != "application/octet-stream" {
+		http.Error(w, "expected octet-stream download", http.StatusBadRequest)
+		return
+	}
+	if f.redirect {
+		http.Redirect(w, r, f.URL+"/storage/"+r.PathValue("id"), http.StatusFound)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, _ = w.Write(f.assets[id].content)
+}
+
+func (f *fakeReleaseServer) serveStorage(w http.ResponseWriter, r *http.Request) {
+	id, ok := f.assetID(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, _ = w.Write(f.assets[id].content)
+}
+
+func (f *fakeReleaseServer) deleteAsset(w http.ResponseWriter, r *http.Request) {
+	id, ok := f.assetID(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	f.mu.Lock()
+	delete(f.assets, id)
+	f.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeReleaseServer) uploadAsset(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "missing name", http.StatusUnprocessableEntity)
+		return
+	}
+	var release int64
+	if err := json.Unmarshal([]byte(r.PathValue("release")), &release); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id := f.addReleaseAsset(release, name, body)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "name": name})
+}
+
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	return dir
+}
+
+// testPrivateRelease is the private release the tests configure.
+var testPrivateRelease = models.ReleaseConfig{Owner: releaseOwner, Repo: "collections", Tag: "private"}
+
+// usePrivateRelease configures testPrivateRelease in the working directory and
+// serves it from f, returning its ID.
+func usePrivateRelease(t *testing.T, f *fakeReleaseServer) int64 {
+	t.Helper()
+	data, err := json.Marshal(models.Config{PrivateRelease: &testPrivateRelease})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigFile, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return f.addRelease(testPrivateRelease)
+}
+
+func TestPullReleaseAsset(t *testing.T) {
+	for _, redirect := range []bool{false, true} {
+		f := newFakeReleaseServer(t)
+		f.redirect = redirect
+		chdirTemp(t)
+		private := usePrivateRelease(t, f)
+
+		want := `[{"id":1,"title":"Dune","myThoughts":"Great"}]`
+		f.addReleaseAsset(private, "books.json", []byte(want))
+		f.addAsset("books.json", []byte(`[{"id":1,"title":"Dune"}]`))
+		f.addReleaseAsset(private, "games.json", []byte(`[]`))
+
+		if err := PullReleaseAsset("books.json"); err != nil {
+			t.Fatalf("redirect=%v: PullReleaseAsset: %v", redirect, err)
+		}
+
+		got, err := os.ReadFile("books.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("redirect=%v: pulled %q, want %q", redirect, got, want)
+		}
+	}
+}
+
+func TestPullReleaseAssetMissing(t *testing.T) {
+	f := newFakeReleaseServer(t)
+	chdirTemp(t)
+	usePrivateRelease(t, f)
+
+	if err := PullReleaseAsset("books.json"); err == nil {
+		t.Fatal("expected an error for a missing asset")
+	}
+	if _, err := os.Stat("books.json"); !os.IsNotExist(err) {
+		t.Errorf("books.json should not have been created, stat err = %v", err)
+	}
+}
+
+func TestPullReleaseAssetRefusesPublicProjection(t *testing.T) {
+	f := newFakeReleaseServer(t)
+	chdirTemp(t)
+
+	local := `[{"id":1,"title":"Dune","myThoughts":"Great"},{"id":2,"title":"Diary","private":true}]`
+	if err := os.WriteFile("books.json", []byte(local), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f.addAsset("books.json", []byte(`[{"id":1,"title":"Dune"}]`))
+
+	if err := PullReleaseAsset("books.json"); err == nil {
+		t.Fatal("expected pulling the public books.json to be refused")
+	}
+	got, err := os.ReadFile("books.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != local {
+		t.Errorf("books.json became %q, want it left as %q", got, local)
+	}
+}
+
+func TestPublishCollectionReplacesExisting(t *testing.T) {
+	f := newFakeReleaseServer(t)
+	chdirTemp(t)
+
+	f.addAsset("books.json", []byte(`[]`))
+	if err := os.WriteFile("books.json", []byte(`[{"id":2,"title":"Mistborn","status":"Reading"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PublishCollection("books.json"); err != nil {
+		t.Fatalf("PublishCollection: %v", err)
+	}
+
+	got, count := f.assetByName("books.json")
+	if count != 1 {
+		t.Fatalf("release has %d books.json assets, want 1", count)
+	}
+	var published []map[string]any
+	if err := json.Unmarshal(got, &published); err != nil {
+		t.Fatal(err)
+	}
+	if len(published) != 1 || published[0]["title"] != "Mistborn" {
+		t.Errorf("uploaded %s, want Mistborn", got)
+	}
+}
+
+func TestPublishCollectionUploadsFullFileToPrivateRelease(t *testing.T) {
+	f := newFakeReleaseServer(t)
+	chdirTemp(t)
+	private := usePrivateRelease(t, f)
+
+	local := `[{"id":1,"title":"Dune","status":"Finished","myThoughts":"Great","privateFields":["myThoughts"]},{"id":2,"title":"Diary","status":"Reading","private":true}]`
+	if err := os.WriteFile("books.json", []byte(local), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f.addReleaseAsset(private, "books.json", []byte(`[]`))
+
+	started := time.Now().UTC().Truncate(time.Second)
+	if err := PublishCollection("books.json"); err != nil {
+		t.Fatalf("PublishCollection: %v", err)
+	}
+
+	got, count := f.releaseAssetByName(private, "books.json")
+	if count != 1 || string(got) != local {
+		t.Errorf("private release has %d books.json assets, last %q; want one holding the local file", count, got)
+	}
+	got, _ = f.assetByName("books.json")
+	var published []map[string]any
+	if err := json.Unmarshal(got, &published); err != nil {
+		t.Fatal(err)
+	}
+	if len(published) != 1 || published[0]["myThoughts"] != nil {
+		t.Errorf("public release has %s, want Dune without its thoughts", got)
+	}
+
+	last, err := loadPublished()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if at, ok := last["books.json"]; !ok || at.Before(started) {
+		t.Errorf("books.json recorded as published at %v, want at least %v", at, started)
+	}
+}
+
+func TestPublishCollectionRecordsNothingOnFailure(t *testing.T) {
+	f := newFakeReleaseServer(t)
+	f.Close()
+	chdirTemp(t)
+
+	if err := os.WriteFile("books.json", []byte(`[]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := PublishCollection("books.json"); err == nil {
+		t.Fatal("expected an error with the release unreachable")
+	}
+	last, err := loadPublished()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := last["books.json"]; ok {
+		t.Errorf("books.json recorded as published after a failed upload")
+	}
+}
+
+func TestNewGitHubClientRequiresToken(t *testing.T) {
+	chdirTemp(t)
+	t.Setenv("GITHUB_TOKEN", "")
+
+	if _, err := NewGitHubClient(context.Background()); err == nil {
+		t.Fatal("expected an error without GITHUB_TOKEN")
+	}
+}
+
+func TestNewGitHubClientURLs(t *testing.T) {
+	chdirTemp(t)
+	t.Setenv("GITHUB_TOKEN", "test-token")
+
+	tests := []struct {
+		api, upload         string
+		wantAPI, wantUpload string
+	}{
+		{"", "", "https://api.github.com/", "https://uploads.github.com/"},
+		{"https://ghe.example.com/api/v3", "", "https://ghe.example.com/api/v3/", "https://ghe.example.com/api/uploads/"},
+		{"https://ghe.example.com/api/v3/", "", "https://ghe.example.com/api/v3/", "https://ghe.example.com/api/uploads/"},
+		{"https://git.example.com/api", "", "https://git.example.com/api/", "https://git.example.com/api/"},
+		{"https://git.example.com/api", "https://up.example.com", "https://git.example.com/api/", "https://up.example.com/"},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("GITHUB_API_URL", tt.api)
+		t.Setenv("GITHUB_UPLOAD_URL", tt.upload)
+
+		client, err := NewGitHubClient(context.Background())
+		if err != nil {
+			t.Fatalf("api=%q upload=%q: %v", tt.api, tt.upload, err)
+		}
+		if got := client.BaseURL.String(); got != tt.wantAPI {
+			t.Errorf("api=%q: BaseURL = %q, want %q", tt.api, got, tt.wantAPI)
+		}
+		if got := client.UploadURL.String(); got != tt.wantUpload {
+			t.Errorf("api=%q upload=%q: UploadURL = %q, want %q", tt.api, tt.upload, got, tt.wantUpload)
+		}
+	}
+
+	t.Setenv("GITHUB_API_URL", "")
+	t.Setenv("GITHUB_UPLOAD_URL", "https://up.example.com")
+	if _, err := NewGitHubClient(context.Background()); err == nil {
+		t.Error("expected an error when only GITHUB_UPLOAD_URL is set")
+	}
+}
+
+func TestPublishCollectionLeavesOutPrivateData(t *testing.T) {
+	f := newFakeReleaseServer(t)
+	chdirTemp(t)
+
+	books := `[
+		{"id":1,"title":"Dune","status":"Finished","myThoughts":"Great","privateFields":["myThoughts"]},
+		{"id":2,"title":"Diary","status":"Reading","private":true}
+	]`
+	if err := os.WriteFile("books.json", []byte(books), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PublishCollection("books.json"); err != nil {
+		t.Fatalf("PublishCollection: %v", err)
+	}
+
+	got, _ := f.assetByName("books.json")
+	var published []map[string]any
+	if err := json.Unmarshal(got, &published); err != nil {
+		t.Fatal(err)
+	}
+	if len(published) != 1 || published[0]["title"] != "Dune" {
+		t.Fatalf("published %s, want only Dune", got)
+	}
+	for _, key := range []string{"myThoughts", "privateFields", "private"} {
+		if _, ok := published[0][key]; ok {
+			t.Errorf("published book has private key %q", key)
+		}
+	}
+}
+
+func TestPublishCollectionKeepsPrivateContributorsOutOfAuthor(t *testing.T) {
+	f := newFakeReleaseServer(t)
+	chdirTemp(t)
+
+	files := map[string]string{
+		PeopleFile: `[{"id":1,"name":"Frank Herbert"},{"id":2,"name":"Jane Doe"}]`,
+		"books.json": `[
+			{"id":1,"title":"Dune","author":"Frank Herbert","status":"Finished","contributors":[{"personId":1,"role":"author"}]},
+			{"id":2,"title":"Notes","author":"Anonymous","status":"Reading","contributors":[{"personId":2,"role":"author"}],"privateFields":["contributors"]}
+		]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := PublishCollection("books.json"); err != nil {
+		t.Fatalf("PublishCollection: %v", err)
+	}
+	got, _ := f.assetByName("books.json")
+	if bytes.Contains(got, []byte("Jane Doe")) {
+		t.Errorf("published %s, naming a private contributor", got)
+	}
+	var published []map[string]any
+	if err := json.Unmarshal(got, &published); err != nil {
+		t.Fatal(err)
+	}
+	if len(published) != 2 || published[0]["author"] != "Frank Herbert" || published[1]["author"] != "Anonymous" {
+		t.Errorf("published %s, want authors Frank Herbert and Anonymous", got)
+	}
+}
+
+func TestPublishCollectionGameSessions(t *testing.T) {
+	sessions := `"sessions":[{"date":"2026-03-01","minutes":90},{"date":"2026-03-04","minutes":30}]`
+	tests := []struct {
+		name   string
+		config string
+		game   string
+		want   bool // Whether sessions, playtime and last played are published.
+	}{
+		{"published by default", `{}`, `{"id":1,"title":"Hades","status":"Playing",` + sessions + `}`, true},
+		{"without timestamps", `{"games":{"exportTimestamps":false}}`, `{"id":1,"title":"Hades","status":"Playing",` + sessions + `}`, true},
+		{"private for the collection", `{"games":{"privateFields":["sessions"]}}`, `{"id":1,"title":"Hades","status":"Playing",` + sessions + `}`, false},
+		{"private for the game", `{}`, `{"id":1,"title":"Hades","status":"Playing","privateFields":["sessions"],` + sessions + `}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeReleaseServer(t)
+			chdirTemp(t)
+			files := map[string]string{ConfigFile: tt.config, "games.json": "[" + tt.game + "]"}
+			for name, content := range files {
+				if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := PublishCollection("games.json"); err != nil {
+				t.Fatalf("PublishCollection: %v", err)
+			}
+			got, _ := f.assetByName("games.json")
+			var published []map[string]any
+			if err := json.Unmarshal(got, &published); err != nil {
+				t.Fatal(err)
+			}
+			if len(published) != 1 {
+				t.Fatalf("published %s, want one game", got)
+			}
+			game := published[0]
+			if tt.want {
+				if game["lastPlayed"] != "2026-03-04" || game["playtimeMinutes"] != 120.0 || game["sessions"] == nil {
+					t.Errorf("published %s, want its sessions, 120 minutes and last played 2026-03-04", got)
+				}
+				return
+			}
+			for _, key := range []string{"sessions", "playtimeMinutes", "lastPlayed"} {
+				if _, ok := game[key]; ok {
+					t.Errorf("published %s with private key %q", got, key)
+				}
+			}
+		})
+	}
+}
+
+func TestPublishCollectionHoldsBackScheduledBooks(t *testing.T) {
+	f := newFakeReleaseServer(t)
+	chdirTemp(t)
+
+	publishAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	books := []map[string]any{
+		{"id": 1, "title": "Dune", "status": "Finished"},
+		{"id": 2, "title": "Emma", "status": "Reading", "draft": true},
+		{"id": 3, "title": "Mistborn", "status": "Reading", "publishAt": publishAt},
+	}
+	data, _ := json.Marshal(books)
+	if err := os.WriteFile("books.json", data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PublishCollection("books.json"); err != nil {
+		t.Fatalf("PublishCollection: %v", err)
+	}
+	got, _ := f.assetByName("books.json")
+	var published []map[string]any
+	if err := json.Unmarshal(got, &published); err != nil {
+		t.Fatal(err)
+	}
+	if len(published) != 1 || published[0]["title"] != "Dune" {
+		t.Fatalf("published %s, want only Dune", got)
+	}
+
+	if due, err := DueCollections(time.Now()); err != nil || len(due) != 0 {
+		t.Fatalf("DueCollections now = %v, %v; want nothing due", due, err)
+	}
+	due, err := DueCollections(publishAt.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || due[0] != "books.json" {
+		t.Errorf("DueCollections after the schedule = %v, want [books.json]", due)
+	}
+}
+
+func TestPublishCollectionLeavesOutReviewsOfWithheldBooks(t *testing.T) {
+	f := newFakeReleaseServer(t)
+	chdirTemp(t)
+
+	files := map[string]string{
+		ConfigFile: `{"books":{"maxWarning":"mild"}}`,
+		"books.json": `[
+			{"id":1,"title":"Dune","status":"Finished"},
+			{"id":2,"title":"Berserk","status":"Reading","contentWarnings":[{"category":"violence","severity":"severe"}]}
+		]`,
+		"reviews.json": `[
+			{"id":1,"bookId":1,"chapter":1,"rating":4},
+			{"id":2,"bookId":2,"chapter":1,"rating":5}
+		]`,
+		ArcsFile: `[
+			{"id":1,"bookId":1,"name":"Arrakis","first":{"chapter":1},"last":{"chapter":2}},
+			{"id":2,"bookId":2,"name":"Golden Age","first":{"chapter":1},"last":{"chapter":2}}
+		]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, fileName := range []string{"reviews.json", ArcsFile} {
+		if err := PublishCollection(fileName); err != nil {
+			t.Fatalf("PublishCollection(%s): %v", fileName, err)
+		}
+		got, _ := f.assetByName(fileName)
+		var published []map[string]any
+		if err := json.Unmarshal(got, &published); err != nil {
+			t.Fatal(err)
+		}
+		if len(published) != 1 || published[0]["bookId"] != 1.0 {
+			t.Errorf("published %s as %s, want only the entry for book 1", fileName, got)
+		}
+	}
+}
+
+func TestPublishCollectionLeavesOutPrivateProjects(t *testing.T) {
+	f := newFakeReleaseServer(t)
+	chdirTemp(t)
+
+	files := map[string]string{
+		ConfigFile: `{"projects":{"privateFields":["installCommand"]}}`,
+		"projects.json": `[
+			{"id":1,"name":"utilodactyl","source":"https://example.com/u","installCommand":"go install","privateFields":["source"]},
+			{"id":2,"name":"secret","private":true}
+		]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := PublishCollection("projects.json"); err != nil {
+		t.Fatalf("PublishCollection: %v", err)
+	}
+	got, _ := f.assetByName("projects.json")
+	var published []map[string]any
+	if err := json.Unmarshal(got, &published); err != nil {
+		t.Fatal(err)
+	}
+	if len(published) != 1 || published[0]["name"] != "utilodactyl" {
+		t.Fatalf("published %s, want only utilodactyl", got)
+	}
+	for _, key := range []string{"source", "installCommand", "privateFields", "private"} {
+		if _, ok := published[0][key]; ok {
+			t.Errorf("published project has private key %q", key)
+		}
+	}
+}
+
+func TestPublishCollectionHoldsBackDraftReviews(t *testing.T) {
+	f := newFakeReleaseServer(t)
+	chdirTemp(t)
+
+	files := map[string]string{
+		"books.json": `[{"id":1,"title":"Dune","status":"Reading"}]`,
+		"reviews.json": `[
+			{"id":1,"bookId":1,"chapter":1,"rating":4},
+			{"id":2,"bookId":1,"chapter":2,"rating":1,"draft":true}
+		]`,
+		ArcsFile: `[{"id":1,"bookId":1,"name":"Arrakis","first":{"chapter":1},"last":{"chapter":2}}]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := PublishCollection("reviews.json"); err != nil {
+		t.Fatalf("PublishCollection(reviews.json): %v", err)
+	}
+	got, _ := f.assetByName("reviews.json")
+	var reviews []map[string]any
+	if err := json.Unmarshal(got, &reviews); err != nil {
+		t.Fatal(err)
+	}
+	if len(reviews) != 1 || reviews[0]["id"] != 1.0 {
+		t.Errorf("published reviews %s, want only review 1", got)
+	}
+
+	if err := PublishCollection(ArcsFile); err != nil {
+		t.Fatalf("PublishCollection(%s): %v", ArcsFile, err)
+	}
+	got, _ = f.assetByName(ArcsFile)
+	var arcs []map[string]any
+	if err := json.Unmarshal(got, &arcs); err != nil {
+		t.Fatal(err)
+	}
+	if len(arcs) != 1 || arcs[0]["count"] != 1.0 || arcs[0]["average"] != 4.0 {
+		t.Errorf("published arcs %s, want the draft review left out of the statistics", got)
+	}
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"utilodactyl/models"
+)
+
+// joinLabel joins the non-empty parts of a picker label.
+func joinLabel(parts ...string) string {
+	kept := parts[:0]
+	for _, p := range parts {
+		if strings.TrimSpace(p) != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, " · ")
+}
+
+// BookLabel describes a book in pickers, with enough context to tell apart
+// books that share a title.
+func BookLabel(b models.Book) string {
+	return joinLabel(b.Title, b.Author, string(b.Status), fmt.Sprintf("#%d", b.ID))
+}
+
+func GameLabel(g models.Game) string {
+	return joinLabel(g.Title, g.Developer, string(g.Status), fmt.Sprintf("#%d", g.ID))
+}
+
+func ProjectLabel(p models.Project) string {
+	return joinLabel(p.Name, p.Source, fmt.Sprintf("#%d", p.ID))
+}
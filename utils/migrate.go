@@ -0,0 +1,71 @@
+package utils
+
+import "utilodactyl/models"
+
+// MigrateProjects writes the IDs LoadProjects gives projects that lack them
+// back to projects.json. It returns how many projects were given one.
+func MigrateProjects() (int, error) {
+	projects, err := readJSONFile[models.Project](projectsFile)
+	if err != nil {
+		return 0, err
+	}
+	n := backfillProjectIDs(projects)
+	if n == 0 {
+		return 0, nil
+	}
+	return n, SaveProjects(projects)
+}
+
+// backfillProjectIDs gives every project without an ID (written before
+// projects had IDs) the next free one, in file order, so the same file always
+// gets the same IDs. It returns how many projects were changed.
+func backfillProjectIDs(projects []models.Project) int {
+	var maxID uint32
+	for _, p := range projects {
+		maxID = max(maxID, p.ID)
+	}
+
+	changed := 0
+	for i := range projects {
+		if projects[i].ID == 0 {
+			maxID++
+			projects[i].ID = maxID
+			changed++
+		}
+	}
+	return changed
+}
+
+// MigrateReviews writes the IDs LoadReviews gives reviews that lack them back
+// to reviews.json. It returns how many reviews were given one.
+func MigrateReviews() (int, error) {
+	reviews, err := readJSONFile[models.Review](reviewsFile)
+	if err != nil {
+		return 0, err
+	}
+	n := backfillReviewIDs(reviews)
+	if n == 0 {
+		return 0, nil
+	}
+	return n, SaveReviews(reviews)
+}
+
+// backfillReviewIDs gives every review without an ID (written when the chapter
+// doubled as the ID) the next free one, in file order, so the same file always
+// gets the same IDs. It returns how many reviews were changed.
+func backfillReviewIDs(reviews []models.Review) int {
+	var maxID uint32
+	for _, r := range reviews {
+		maxID = max(maxID, r.ID)
+	}
+
+	changed := 0
+	for i := range reviews {
+		if reviews[i].ID == 0 {
+			maxID++
+			reviews[i].ID = maxID
+			changed++
+		}
+	}
+	return changed
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+	"utilodactyl/models"
+)
+
+const outboxFile = "outbox.json"
+
+func LoadOutbox() ([]models.OutboxEntry, error) {
+	return readJSONFile[models.OutboxEntry](outboxFile)
+}
+
+func SaveOutbox(entries []models.OutboxEntry) error {
+	return writeJSONFile(outboxFile, entries)
+}
+
+// QueueUpload records a failed upload of fileName in the outbox. Uploads always
+// send the current local file, so a collection that is already queued keeps its
+// place in the queue instead of being added twice.
+func QueueUpload(fileName string, cause error) error {
+	entries, err := LoadOutbox()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range entries {
+		if entries[i].FileName == fileName {
+			entries[i].UpdatedAt = now
+			entries[i].Attempts++
+			entries[i].LastError = cause.Error()
+			return SaveOutbox(entries)
+		}
+	}
+
+	entries = append(entries, models.OutboxEntry{
+		FileName:  fileName,
+		QueuedAt:  now,
+		UpdatedAt: now,
+		Attempts:  1,
+		LastError: cause.Error(),
+	})
+	return SaveOutbox(entries)
+}
+
+// DequeueUpload removes fileName from the outbox, if it is queued.
+func DequeueUpload(fileName string) error {
+	entries, err := LoadOutbox()
+	if err != nil {
+		return err
+	}
+
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.FileName != fileName {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == len(entries) {
+		return nil
+	}
+	return SaveOutbox(kept)
+}
+
+// UploadOrQueue uploads fileName to the release. If the release cannot be
+// reached, the collection is queued in the outbox for the next sync; other
+// failures are returned as they are, since retrying would not fix them.
+func UploadOrQueue(fileName string) error {
+	if err := PublishCollection(fileName); err != nil {
+		if !retryable(err) {
+			return err
+		}
+		if qerr := QueueUpload(fileName, err); qerr != nil {
+			return fmt.Errorf("%w (and failed to queue it for later: %v)", err, qerr)
+		}
+		return fmt.Errorf("%w; %s was queued for the next sync", err, fileName)
+	}
+	return DequeueUpload(fileName)
+}
+
+// FlushOutbox uploads the queued collections in the order they were queued.
+// It stops at the first upload that could not reach the release, so later
+// uploads never overtake earlier ones. Uploads that failed for any other
+// reason would fail the same way on every sync, so they are taken out of the
+// queue and returned with their error instead of blocking it. It also returns
+// how many uploads were flushed.
+func FlushOutbox(upload func(fileName string) error) (int, []models.OutboxEntry, error) {
+	entries, err := LoadOutbox()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	flushed := 0
+	var dropped []models.OutboxEntry
+	for len(entries) > 0 {
+		entry := entries[0]
+		if err := upload(entry.FileName); err != nil {
+			entries[0].Attempts++
+			entries[0].LastError = err.Error()
+			if !retryable(err) {
+				dropped = append(dropped, entries[0])
+				entries = entries[1:]
+				if serr := SaveOutbox(entries); serr != nil {
+					return flushed, dropped, serr
+				}
+				continue
+			}
+			if serr := SaveOutbox(entries); serr != nil {
+				return flushed, dropped, serr
+			}
+			return flushed, dropped, fmt.Errorf("failed to upload %s: %w", entry.FileName, err)
+		}
+
+		entries = entries[1:]
+		if err := SaveOutbox(entries); err != nil {
+			return flushed, dropped, err
+		}
+		flushed++
+	}
+	return flushed, dropped, nil
+}
+
+// PendingUploadsBanner describes the outbox for the main menu. It returns an
+// empty string when nothing is waiting to be uploaded.
+func PendingUploadsBanner() string {
+	entries, err := LoadOutbox()
+	if err != nil || len(entries) == 0 {
+		return ""
+	}
+	if len(entries) == 1 {
+		return "1 collection pending upload"
+	}
+	return fmt.Sprintf("%d collections pending upload", len(entries))
+}
@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"testing"
+	"utilodactyl/models"
+
+	"github.com/google/go-github/github"
+)
+
+func outboxFiles(t *testing.T) []string {
+	t.Helper()
+	entries, err := LoadOutbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make([]string, len(entries))
+	for i, e := range entries {
+		files[i] = e.FileName
+	}
+	return files
+}
+
+func TestQueueUploadCoalesces(t *testing.T) {
+	chdirTemp(t)
+
+	for _, q := range []struct {
+		fileName string
+		cause    string
+	}{
+		{"books.json", "first"},
+		{"games.json", "offline"},
+		{"books.json", "second"},
+	} {
+		if err := QueueUpload(q.fileName, errors.New(q.cause)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := LoadOutbox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].FileName != "books.json" || entries[1].FileName != "games.json" {
+		t.Fatalf("outbox = %+v, want books.json then games.json", entries)
+	}
+	books := entries[0]
+	if books.Attempts != 2 || books.LastError != "second" {
+		t.Errorf("books.json entry = %+v, want 2 attempts and the last error", books)
+	}
+	if books.UpdatedAt.Before(books.QueuedAt) {
+		t.Errorf("books.json updated at %v, before it was queued at %v", books.UpdatedAt, books.QueuedAt)
+	}
+}
+
+func TestDequeueUpload(t *testing.T) {
+	chdirTemp(t)
+
+	// Dequeuing from a missing outbox does not create one.
+	if err := DequeueUpload("books.json"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(outboxFile); !os.IsNotExist(err) {
+		t.Fatalf("%s should not exist, stat err = %v", outboxFile, err)
+	}
+
+	for _, name := range []string{"books.json", "games.json", "reviews.json"} {
+		if err := QueueUpload(name, errors.New("offline")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := DequeueUpload("games.json"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DequeueUpload("projects.json"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := outboxFiles(t), []string{"books.json", "reviews.json"}; !slices.Equal(got, want) {
+		t.Errorf("outbox = %v, want %v", got, want)
+	}
+}
+
+func TestFlushOutbox(t *testing.T) {
+	tests := []struct {
+		name        string
+		offline     string // Fails as if the release could not be reached.
+		broken      string // Fails in a way a retry would not fix.
+		wantFlushed int
+		wantUpload  []string
+		wantLeft    []string
+	}{
+		{"all succeed", "", "", 3, []string{"books.json", "games.json", "reviews.json"}, []string{}},
+		{"stops at the first failure", "games.json", "", 1, []string{"books.json", "games.json"}, []string{"games.json", "reviews.json"}},
+		{"head fails", "books.json", "", 0, []string{"books.json"}, []string{"books.json", "games.json", "reviews.json"}},
+		{"drops what a retry would not fix", "", "books.json", 2, []string{"books.json", "games.json", "reviews.json"}, []string{}},
+		{"drops, then stops", "reviews.json", "games.json", 1, []string{"books.json", "games.json", "reviews.json"}, []string{"reviews.json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t)
+			for _, name := range []string{"books.json", "games.json", "reviews.json"} {
+				if err := QueueUpload(name, errors.New("offline")); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var uploaded []string
+			flushed, dropped, err := FlushOutbox(func(fileName string) error {
+				uploaded = append(uploaded, fileName)
+				switch fileName {
+				case tt.offline:
+					return &releaseError{errors.New("still offline")}
+				case tt.broken:
+					return errors.New("invalid entry")
+				}
+				return nil
+			})
+			if (err != nil) != (tt.offline != "") {
+				t.Fatalf("FlushOutbox error = %v", err)
+			}
+			if flushed != tt.wantFlushed {
+				t.Errorf("flushed %d, want %d", flushed, tt.wantFlushed)
+			}
+			if !slices.Equal(uploaded, tt.wantUpload) {
+				t.Errorf("uploaded %v, want %v", uploaded, tt.wantUpload)
+			}
+			if got := outboxFiles(t); !slices.Equal(got, tt.wantLeft) {
+				t.Errorf("outbox left with %v, want %v", got, tt.wantLeft)
+			}
+
+			if tt.broken != "" {
+				if len(dropped) != 1 || dropped[0].FileName != tt.broken || dropped[0].LastError != "invalid entry" {
+					t.Errorf("dropped %+v, want %s with its error", dropped, tt.broken)
+				}
+			} else if len(dropped) != 0 {
+				t.Errorf("dropped %+v, want nothing", dropped)
+			}
+			if tt.offline != "" {
+				entries, _ := LoadOutbox()
+				if entries[0].Attempts != 2 || entries[0].LastError != "still offline" {
+					t.Errorf("failed entry = %+v, want 2 attempts and the new error", entries[0])
+				}
+			}
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	status := func(code int) error {
+		return &releaseError{fmt.Errorf("error uploading asset: %w",
+			&github.ErrorResponse{Response: &http.Response{StatusCode: code, Request: &http.Request{}}})}
+	}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network error", &releaseError{errors.New("connection refused")}, true},
+		{"server error", status(http.StatusBadGateway), true},
+		{"rate limited", status(http.StatusTooManyRequests), true},
+		{"timed out", status(http.StatusRequestTimeout), true},
+		{"wrapped again", fmt.Errorf("publishing: %w", status(http.StatusServiceUnavailable)), true},
+		{"release not found", status(http.StatusNotFound), false},
+		{"upload rejected", status(http.StatusUnprocessableEntity), false},
+		{"local failure", errors.New("books.json is invalid"), false},
+	}
+
+	for _, tt := range tests {
+		if got := retryable(tt.err); got != tt.want {
+			t.Errorf("%s: retryable(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestUploadOrQueue(t *testing.T) {
+	// An unreachable release is queued for later.
+	f := newFakeReleaseServer(t)
+	f.Close()
+	chdirTemp(t)
+	if err := os.WriteFile("books.json", []byte(`[]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := UploadOrQueue("books.json"); err == nil {
+		t.Fatal("expected an error with the release unreachable")
+	}
+	if got := outboxFiles(t); !slices.Equal(got, []string{"books.json"}) {
+		t.Errorf("outbox = %v, want books.json queued", got)
+	}
+
+	// Failures a retry would not fix are returned without queuing.
+	permanent := []struct {
+		name  string
+		setup func(t *testing.T)
+	}{
+		{"missing token", func(t *testing.T) {
+			t.Setenv("GITHUB_TOKEN", "")
+			if err := os.WriteFile("books.json", []byte(`[]`), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"invalid collection", func(t *testing.T) {
+			newFakeReleaseServer(t)
+			if err := os.WriteFile("books.json", []byte(`[{"id":1,"title":"Dune","rating":7}]`), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"invalid config", func(t *testing.T) {
+			newFakeReleaseServer(t)
+			data, _ := json.Marshal(models.Config{PrivateRelease: &models.ReleaseConfig{Owner: releaseOwner}})
+			if err := os.WriteFile(ConfigFile, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}},
+	}
+	for _, tt := range permanent {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t)
+			tt.setup(t)
+			if err := UploadOrQueue("books.json"); err == nil {
+				t.Fatal("expected an error")
+			}
+			if _, err := os.Stat(outboxFile); !os.IsNotExist(err) {
+				t.Errorf("%s should not exist, stat err = %v", outboxFile, err)
+			}
+		})
+	}
+}
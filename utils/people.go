@@ -0,0 +1,238 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"utilodactyl/models"
+)
+
+// PeopleFile holds the people and studios credited by the collections.
+const PeopleFile = "people.json"
+
+var (
+	// BookRoles are the roles a person can have on a book, the first being
+	// the one every book needs.
+	BookRoles = []models.Role{models.RoleAuthor, models.RoleTranslator, models.RoleIllustrator}
+
+	// Roles lists every known role.
+	Roles = []models.Role{
+		models.RoleAuthor, models.RoleTranslator, models.RoleIllustrator,
+		models.RoleDeveloper, models.RolePublisher,
+	}
+)
+
+func LoadPeople() ([]models.Person, error) {
+	return readJSONFile[models.Person](PeopleFile)
+}
+
+func SavePeople(people []models.Person) error {
+	return writeJSONFile(PeopleFile, people)
+}
+
+// FindPerson returns the index of the person whose name or one of whose
+// aliases is name, ignoring case and surrounding spaces, or -1.
+func FindPerson(people []models.Person, name string) int {
+	name = strings.TrimSpace(name)
+	return slices.IndexFunc(people, func(p models.Person) bool {
+		return strings.EqualFold(p.Name, name) || slices.ContainsFunc(p.Aliases, func(a string) bool {
+			return strings.EqualFold(a, name)
+		})
+	})
+}
+
+// PersonName returns the name of the person with the given ID, or "" if the
+// registry has no such person.
+func PersonName(people []models.Person, id uint32) string {
+	for _, p := range people {
+		if p.ID == id {
+			return p.Name
+		}
+	}
+	return ""
+}
+
+// ResolvePerson returns the ID of the person called name, adding them to the
+// registry if they are new, and records that they were credited with role.
+func ResolvePerson(people *[]models.Person, name string, role models.Role) uint32 {
+	i := FindPerson(*people, name)
+	if i < 0 {
+		var id uint32
+		for _, p := range *people {
+			id = max(id, p.ID)
+		}
+		*people = append(*people, models.Person{ID: id + 1, Name: strings.TrimSpace(name)})
+		i = len(*people) - 1
+	}
+	p := &(*people)[i]
+	if role != "" && !slices.Contains(p.Roles, role) {
+		p.Roles = append(p.Roles, role)
+	}
+	return p.ID
+}
+
+// CanonicalPerson returns the registry name of name if it is a known name or
+// alias, and name itself otherwise.
+func CanonicalPerson(people []models.Person, name string) string {
+	if i := FindPerson(people, name); i >= 0 {
+		return people[i].Name
+	}
+	return strings.TrimSpace(name)
+}
+
+// PersonSuggestions lists the names and aliases of the people credited with
+// role, or of everyone when role is empty, for autocompletion.
+func PersonSuggestions(people []models.Person, role models.Role) []string {
+	var names []string
+	for _, p := range people {
+		if role == "" || slices.Contains(p.Roles, role) {
+			names = append(names, p.Name)
+			names = append(names, p.Aliases...)
+		}
+	}
+	SortFold(names)
+	return names
+}
+
+// AuthorLine joins the names of the authors among contributors, e.g.
+// "Terry Pratchett & Neil Gaiman".
+func AuthorLine(people []models.Person, contributors []models.Contributor) string {
+	var names []string
+	for _, c := range contributors {
+		if c.Role == models.RoleAuthor {
+			names = append(names, PersonName(people, c.PersonID))
+		}
+	}
+	return strings.Join(names, " & ")
+}
+
+// FormatContributors renders contributors as a single line, e.g.
+// "Haruki Murakami (author), Jay Rubin (translator)".
+func FormatContributors(people []models.Person, contributors []models.Contributor) string {
+	parts := make([]string, len(contributors))
+	for i, c := range contributors {
+		parts[i] = fmt.Sprintf("%s (%s)", PersonName(people, c.PersonID), c.Role)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// LinkBookPeople credits the free-text author of a book that has no
+// contributors yet, adding the author to the registry if needed, and keeps
+// the author string in sync with the contributors. It reports whether the
+// book changed.
+func LinkBookPeople(people *[]models.Person, b *models.Book) bool {
+	before, linked := b.Author, len(b.Contributors) > 0
+	if len(b.Contributors) == 0 && strings.TrimSpace(b.Author) != "" {
+		id := ResolvePerson(people, b.Author, models.RoleAuthor)
+		b.Contributors = []models.Contributor{{PersonID: id, Role: models.RoleAuthor}}
+	}
+	if line := AuthorLine(*people, b.Contributors); line != "" {
+		b.Author = line
+	}
+	return b.Author != before || !linked && len(b.Contributors) > 0
+}
+
+// LinkGamePeople replaces a game's developer with its registry name, adding the
+// developer to the registry if needed. It reports whether the game changed.
+func LinkGamePeople(people *[]models.Person, g *models.Game) bool {
+	if strings.TrimSpace(g.Developer) == "" {
+		return false
+	}
+	id := ResolvePerson(people, g.Developer, models.RoleDeveloper)
+	name := PersonName(*people, id)
+	changed := g.Developer != name
+	g.Developer = name
+	return changed
+}
+
+// MergePeople folds the person from into the person into: from's name and
+// aliases become aliases of into, its roles are added, and every book and game
+// crediting from credits into instead. from is removed from the registry.
+func MergePeople(people []models.Person, books []models.Book, games []models.Game, from, into uint32) []models.Person {
+	fi := slices.IndexFunc(people, func(p models.Person) bool { return p.ID == from })
+	ti := slices.IndexFunc(people, func(p models.Person) bool { return p.ID == into })
+	if fi < 0 || ti < 0 || fi == ti {
+		return people
+	}
+	source, target := people[fi], &people[ti]
+
+	target.Aliases = MergeStrings(target.Aliases, []string{source.Name}, source.Aliases)
+	for _, r := range source.Roles {
+		if !slices.Contains(target.Roles, r) {
+			target.Roles = append(target.Roles, r)
+		}
+	}
+
+	for i := range books {
+		b := &books[i]
+		var merged []models.Contributor
+		for _, c := range b.Contributors {
+			if c.PersonID == from {
+				c.PersonID = into
+			}
+			if !slices.Contains(merged, c) {
+				merged = append(merged, c)
+			}
+		}
+		b.Contributors = merged
+	}
+	for i := range games {
+		if strings.EqualFold(games[i].Developer, source.Name) || slices.ContainsFunc(source.Aliases, func(a string) bool {
+			return strings.EqualFold(a, games[i].Developer)
+		}) {
+			games[i].Developer = target.Name
+		}
+	}
+
+	people = slices.Delete(people, fi, fi+1)
+	for i := range books {
+		if line := AuthorLine(people, books[i].Contributors); line != "" {
+			books[i].Author = line
+		}
+	}
+	return people
+}
+
+// ValidatePeople checks the registry for duplicate IDs, empty names, spellings
+// claimed by two people and unknown roles.
+func ValidatePeople(people []models.Person) error {
+	var errs []error
+	ids := make(map[uint32]bool, len(people))
+	owner := make(map[string]string)
+	for i, p := range people {
+		where := fmt.Sprintf("person #%d (id %d)", i+1, p.ID)
+		if ids[p.ID] {
+			errs = append(errs, fmt.Errorf("%s: duplicate id", where))
+		}
+		ids[p.ID] = true
+		if strings.TrimSpace(p.Name) == "" {
+			errs = append(errs, fmt.Errorf("%s: empty name", where))
+		}
+		for _, spelling := range append([]string{p.Name}, p.Aliases...) {
+			key := strings.ToLower(strings.TrimSpace(spelling))
+			if other, ok := owner[key]; ok && other != p.Name {
+				errs = append(errs, fmt.Errorf("%s: %q is also a spelling of %q", where, spelling, other))
+			}
+			owner[key] = p.Name
+		}
+		for _, r := range p.Roles {
+			if !slices.Contains(Roles, r) {
+				errs = append(errs, fmt.Errorf("%s: unknown role %q", where, r))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateContributors(people []models.Person, contributors []models.Contributor) error {
+	for _, c := range contributors {
+		if PersonName(people, c.PersonID) == "" {
+			return fmt.Errorf("contributor %d is not in %s", c.PersonID, PeopleFile)
+		}
+		if !slices.Contains(Roles, c.Role) {
+			return fmt.Errorf("unknown contributor role %q", c.Role)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"utilodactyl/models"
+)
+
+func TestMergePeople(t *testing.T) {
+	author := func(id uint32) models.Contributor {
+		return models.Contributor{PersonID: id, Role: models.RoleAuthor}
+	}
+	translator := func(id uint32) models.Contributor {
+		return models.Contributor{PersonID: id, Role: models.RoleTranslator}
+	}
+	registry := func() []models.Person {
+		return []models.Person{
+			{ID: 1, Name: "Terry Pratchett", Roles: []models.Role{models.RoleAuthor}},
+			{ID: 2, Name: "T. Pratchett", Aliases: []string{"Pratchett"}, Roles: []models.Role{models.RoleAuthor, models.RoleTranslator}},
+			{ID: 3, Name: "Neil Gaiman", Roles: []models.Role{models.RoleAuthor}},
+			{ID: 4, Name: "FromSoftware", Roles: []models.Role{models.RoleDeveloper}},
+			{ID: 5, Name: "From Software Inc.", Aliases: []string{"FromSoft"}, Roles: []models.Role{models.RoleDeveloper}},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		books       []models.Book
+		games       []models.Game
+		from, into  uint32
+		wantPerson  models.Person // The merged person.
+		wantBooks   []models.Book
+		wantDevs    []string
+		wantRemoved bool
+	}{
+		{
+			name:        "book crediting both collapses to one contributor",
+			books:       []models.Book{{Author: "Terry Pratchett & T. Pratchett", Contributors: []models.Contributor{author(1), author(2)}}},
+			from:        2,
+			into:        1,
+			wantPerson:  models.Person{ID: 1, Name: "Terry Pratchett", Aliases: []string{"T. Pratchett", "Pratchett"}, Roles: []models.Role{models.RoleAuthor, models.RoleTranslator}},
+			wantBooks:   []models.Book{{Author: "Terry Pratchett", Contributors: []models.Contributor{author(1)}}},
+			wantRemoved: true,
+		},
+		{
+			name: "author line recomputed, other roles kept",
+			books: []models.Book{
+				{Author: "T. Pratchett & Neil Gaiman", Contributors: []models.Contributor{author(2), author(3), translator(2)}},
+				{Author: "Neil Gaiman", Contributors: []models.Contributor{author(3)}},
+			},
+			from:       2,
+			into:       1,
+			wantPerson: models.Person{ID: 1, Name: "Terry Pratchett", Aliases: []string{"T. Pratchett", "Pratchett"}, Roles: []models.Role{models.RoleAuthor, models.RoleTranslator}},
+			wantBooks: []models.Book{
+				{Author: "Terry Pratchett & Neil Gaiman", Contributors: []models.Contributor{author(1), author(3), translator(1)}},
+				{Author: "Neil Gaiman", Contributors: []models.Contributor{author(3)}},
+			},
+			wantRemoved: true,
+		},
+		{
+			name:        "game developer matched by name and alias, ignoring case",
+			games:       []models.Game{{Developer: "from software inc."}, {Developer: "FROMSOFT"}, {Developer: "Supergiant"}},
+			from:        5,
+			into:        4,
+			wantPerson:  models.Person{ID: 4, Name: "FromSoftware", Aliases: []string{"From Software Inc.", "FromSoft"}, Roles: []models.Role{models.RoleDeveloper}},
+			wantDevs:    []string{"FromSoftware", "FromSoftware", "Supergiant"},
+			wantRemoved: true,
+		},
+		{
+			name:       "merging into itself does nothing",
+			books:      []models.Book{{Author: "Neil Gaiman", Contributors: []models.Contributor{author(3)}}},
+			from:       3,
+			into:       3,
+			wantPerson: models.Person{ID: 3, Name: "Neil Gaiman", Roles: []models.Role{models.RoleAuthor}},
+			wantBooks:  []models.Book{{Author: "Neil Gaiman", Contributors: []models.Contributor{author(3)}}},
+		},
+		{
+			name:       "unknown person does nothing",
+			games:      []models.Game{{Developer: "FromSoftware"}},
+			from:       9,
+			into:       4,
+			wantPerson: models.Person{ID: 4, Name: "FromSoftware", Roles: []models.Role{models.RoleDeveloper}},
+			wantDevs:   []string{"FromSoftware"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			people := MergePeople(registry(), tt.books, tt.games, tt.from, tt.into)
+
+			if want := len(registry()); tt.wantRemoved && len(people) != want-1 || !tt.wantRemoved && len(people) != want {
+				t.Errorf("registry has %d people after the merge", len(people))
+			}
+			if tt.wantRemoved && PersonName(people, tt.from) != "" {
+				t.Errorf("person %d is still in the registry", tt.from)
+			}
+			var merged models.Person
+			for _, p := range people {
+				if p.ID == tt.wantPerson.ID {
+					merged = p
+				}
+			}
+			if !reflect.DeepEqual(merged, tt.wantPerson) {
+				t.Errorf("merged person = %+v, want %+v", merged, tt.wantPerson)
+			}
+			if !reflect.DeepEqual(tt.books, tt.wantBooks) {
+				t.Errorf("books = %+v, want %+v", tt.books, tt.wantBooks)
+			}
+			for i, g := range tt.games {
+				if g.Developer != tt.wantDevs[i] {
+					t.Errorf("game %d developer = %q, want %q", i, g.Developer, tt.wantDevs[i])
+				}
+			}
+		})
+	}
+}
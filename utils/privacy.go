@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"utilodactyl/models"
+)
+
+// PrivateField is a field that can be kept out of the public release. Keys
+// are the keys of the published entry it covers; by default just its name.
+type PrivateField struct {
+	Name string
+	Keys []string
+}
+
+func (f PrivateField) keys() []string {
+	if len(f.Keys) == 0 {
+		return []string{f.Name}
+	}
+	return f.Keys
+}
+
+var historyKeys = []string{"addedAt", "updatedAt", "finishedAt", "statusHistory"}
+
+// BookPrivateFields lists the book fields that can be made private.
+var BookPrivateFields = []PrivateField{
+	{Name: "myThoughts"},
+	{Name: "description"},
+	{Name: "rating", Keys: []string{"rating", "ratingNormalized"}},
+	{Name: "genres"},
+	{Name: "tags"},
+	{Name: "links"},
+	{Name: "coverImage"},
+	{Name: "contributors"}, // The author line stays public, published as saved.
+	{Name: "contentWarnings"},
+	{Name: "series", Keys: []string{"seriesId", "seriesPosition", "series"}},
+	{Name: "progress", Keys: []string{"format", "length", "position", "percent"}},
+	{Name: "history", Keys: historyKeys},
+}
+
+// GamePrivateFields lists the game fields that can be made private.
+var GamePrivateFields = []PrivateField{
+	{Name: "myThoughts"},
+	{Name: "description"},
+	{Name: "rating", Keys: []string{"rating", "ratingNormalized"}},
+	{Name: "genres"},
+	{Name: "tags"},
+	{Name: "links"},
+	{Name: "coverImage"},
+	{Name: "contentWarnings"},
+	{Name: "progress", Keys: []string{"percent"}},
+	{Name: "sessions", Keys: []string{"sessions", "playtimeMinutes", "lastPlayed"}},
+	{Name: "history", Keys: historyKeys},
+}
+
+// ProjectPrivateFields lists the project fields that can be made private.
+var ProjectPrivateFields = []PrivateField{
+	{Name: "description"},
+	{Name: "tags"},
+	{Name: "source"},
+	{Name: "installCommand"},
+	{Name: "links"},
+}
+
+// ReviewPrivateFields lists the review fields that can be made private.
+var ReviewPrivateFields = []PrivateField{
+	{Name: "description"},
+	{Name: "thoughts"},
+	{Name: "rating", Keys: []string{"rating", "ratingNormalized"}},
+}
+
+// Unpublished returns the names of the fields an entry keeps out of the
+// public release: those the collection makes private and those the entry
+// does.
+func Unpublished(configured, entry []string) map[string]bool {
+	hidden := make(map[string]bool, len(configured)+len(entry))
+	for _, name := range slices.Concat(configured, entry) {
+		hidden[name] = true
+	}
+	return hidden
+}
+
+// privateKeys returns the published keys to drop from an entry's public
+// form, always including its own privacy and scheduling settings.
+func privateKeys(fields []PrivateField, configured, entry []string) []string {
+	keys := []string{"private", "privateFields", "draft", "publishAt"}
+	for _, f := range fields {
+		if slices.Contains(configured, f.Name) || slices.Contains(entry, f.Name) {
+			keys = append(keys, f.keys()...)
+		}
+	}
+	return keys
+}
+
+// validatePrivateFields checks that names are fields that can be made
+// private.
+func validatePrivateFields(fields []PrivateField, names []string) error {
+	var errs []error
+	for _, name := range names {
+		if !slices.ContainsFunc(fields, func(f PrivateField) bool { return f.Name == name }) {
+			errs = append(errs, fmt.Errorf("unknown private field %q (expected %s)", name, privateFieldNames(fields)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkPrivateConfig checks the private fields a collection is configured
+// with.
+func checkPrivateConfig(fields []PrivateField, cfg models.CollectionConfig) error {
+	if err := validatePrivateFields(fields, cfg.PrivateFields); err != nil {
+		return fmt.Errorf("invalid privateFields in %s: %w", ConfigFile, err)
+	}
+	return nil
+}
+
+func privateFieldNames(fields []PrivateField) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// omitKeys encodes v, a struct, as a JSON object without the given keys. The
+// remaining keys keep their order.
+func omitKeys(v any, keys []string) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	out.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		if slices.Contains(keys, key) {
+			continue
+		}
+		if out.Len() > 1 {
+			out.WriteByte(',')
+		}
+		encodedKey, _ := json.Marshal(key)
+		out.Write(encodedKey)
+		out.WriteByte(':')
+		out.Write(value)
+	}
+	out.WriteByte('}')
+	return out.Bytes(), nil
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOmitKeys(t *testing.T) {
+	type entry struct {
+		ID      int      `json:"id"`
+		Title   string   `json:"title"`
+		Tags    []string `json:"tags"`
+		Nested  struct{ A, B int }
+		Private bool `json:"private,omitempty"`
+	}
+	v := entry{ID: 1, Title: "Dune", Tags: []string{"classic"}, Private: true}
+	v.Nested.A = 2
+
+	tests := []struct {
+		keys []string
+		want string
+	}{
+		{nil, `{"id":1,"title":"Dune","tags":["classic"],"Nested":{"A":2,"B":0},"private":true}`},
+		{[]string{"title"}, `{"id":1,"tags":["classic"],"Nested":{"A":2,"B":0},"private":true}`},
+		{[]string{"id", "private"}, `{"title":"Dune","tags":["classic"],"Nested":{"A":2,"B":0}}`},
+		// Only top-level keys are dropped, and unknown keys are ignored.
+		{[]string{"A", "missing"}, `{"id":1,"title":"Dune","tags":["classic"],"Nested":{"A":2,"B":0},"private":true}`},
+		{[]string{"id", "title", "tags", "Nested", "private"}, `{}`},
+	}
+
+	for _, tt := range tests {
+		got, err := omitKeys(v, tt.keys)
+		if err != nil {
+			t.Fatalf("omitKeys(%q): %v", tt.keys, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("omitKeys(%q) = %s, want %s", tt.keys, got, tt.want)
+		}
+	}
+}
+
+func TestPrivateKeys(t *testing.T) {
+	always := []string{"private", "privateFields", "draft", "publishAt"}
+	tests := []struct {
+		name              string
+		configured, entry []string
+		want              []string
+	}{
+		{"nothing private", nil, nil, always},
+		{"configured", []string{"myThoughts"}, nil, append(slices.Clone(always), "myThoughts")},
+		{"per entry", nil, []string{"description"}, append(slices.Clone(always), "description")},
+		{"both, in field order", []string{"tags"}, []string{"myThoughts"}, append(slices.Clone(always), "myThoughts", "tags")},
+		{"fields covering several keys", []string{"rating"}, []string{"history"},
+			append(slices.Clone(always), "rating", "ratingNormalized", "addedAt", "updatedAt", "finishedAt", "statusHistory")},
+		{"named twice", []string{"genres"}, []string{"genres"}, append(slices.Clone(always), "genres")},
+		{"unknown names", []string{"nope"}, []string{"missing"}, always},
+	}
+
+	for _, tt := range tests {
+		if got := privateKeys(BookPrivateFields, tt.configured, tt.entry); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: privateKeys = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
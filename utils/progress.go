@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"utilodactyl/models"
+)
+
+// BookFormats lists the formats a book can be read in.
+var BookFormats = []models.BookFormat{models.FormatPhysical, models.FormatEbook, models.FormatAudiobook}
+
+// BookUnit is what a book's length and position are counted in.
+func BookUnit(format models.BookFormat) string {
+	if format == models.FormatAudiobook {
+		return "minutes"
+	}
+	return "pages"
+}
+
+// BookPercent is how far through the book its position is, if its length is
+// known.
+func BookPercent(b models.Book) (int, bool) {
+	if b.Length == 0 {
+		return 0, false
+	}
+	// Widened first, since position × 100 overflows uint32 on long books.
+	return int(uint64(min(b.Position, b.Length)) * 100 / uint64(b.Length)), true
+}
+
+// FormatBookProgress renders a book's progress, e.g. "120/300 pages (40%)", or
+// "" when its length is unknown.
+func FormatBookProgress(b models.Book) string {
+	percent, ok := BookPercent(b)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d %s (%d%%)", b.Position, b.Length, BookUnit(b.Format), percent)
+}
+
+func validateBookProgress(b models.Book) error {
+	if b.Format != "" && !slices.Contains(BookFormats, b.Format) {
+		return fmt.Errorf("unknown format %q", b.Format)
+	}
+	if b.Length > 0 && b.Position > b.Length {
+		return fmt.Errorf("position %d is past the end (%d %s)", b.Position, b.Length, BookUnit(b.Format))
+	}
+	return nil
+}
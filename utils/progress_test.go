@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"math"
+	"testing"
+	"utilodactyl/models"
+)
+
+func TestBookPercent(t *testing.T) {
+	tests := []struct {
+		position, length uint32
+		want             int
+		ok               bool
+	}{
+		{0, 0, 0, false},
+		{12, 0, 0, false},
+		{0, 300, 0, true},
+		{120, 300, 40, true},
+		{299, 300, 99, true},
+		{300, 300, 100, true},
+		// Positions past the end count as finished.
+		{400, 300, 100, true},
+		// Lengths where position × 100 no longer fits in 32 bits.
+		{50_000_000, 100_000_000, 50, true},
+		{math.MaxUint32, math.MaxUint32, 100, true},
+		{math.MaxUint32 / 4, math.MaxUint32, 24, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := BookPercent(models.Book{Position: tt.position, Length: tt.length})
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("BookPercent(%d/%d) = %d, %v; want %d, %v", tt.position, tt.length, got, ok, tt.want, tt.ok)
+		}
+	}
+}
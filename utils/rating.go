@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"utilodactyl/models"
+)
+
+// Scale is a rating scale: ratings run from Min to Max in steps of Step.
+type Scale struct {
+	Name models.RatingScale
+	Min  float64
+	Max  float64
+	Step float64
+}
+
+// Scales lists the supported rating scales, the default first.
+var Scales = []Scale{
+	{Name: models.ScaleStars, Min: 1, Max: 5, Step: 1},
+	{Name: models.ScaleHalfStars, Min: 0.5, Max: 5, Step: 0.5},
+	{Name: models.ScaleTen, Min: 1, Max: 10, Step: 1},
+	{Name: models.ScaleHundred, Min: 1, Max: 100, Step: 1},
+}
+
+// FindScale returns the scale with the given name; the empty name is the
+// default scale.
+func FindScale(name models.RatingScale) (Scale, error) {
+	if name == "" {
+		return Scales[0], nil
+	}
+	for _, s := range Scales {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return Scale{}, fmt.Errorf("unknown rating scale %q (expected %s)", name, scaleNames())
+}
+
+func scaleNames() string {
+	names := make([]string, len(Scales))
+	for i, s := range Scales {
+		names[i] = string(s.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// BookScale returns the rating scale configured for books.
+func BookScale() (Scale, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return Scale{}, err
+	}
+	return FindScale(cfg.Books.RatingScale)
+}
+
+// GameScale returns the rating scale configured for games.
+func GameScale() (Scale, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return Scale{}, err
+	}
+	return FindScale(cfg.Games.RatingScale)
+}
+
+// ReviewScale returns the rating scale configured for chapter reviews.
+func ReviewScale() (Scale, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return Scale{}, err
+	}
+	return FindScale(cfg.Reviews.RatingScale)
+}
+
+// Ratings lists every rating the scale allows, lowest first.
+func (s Scale) Ratings() []models.Rating {
+	var ratings []models.Rating
+	for i := 0; ; i++ {
+		r := s.Min + float64(i)*s.Step
+		if r > s.Max {
+			return ratings
+		}
+		ratings = append(ratings, models.Rating(r))
+	}
+}
+
+// onStep reports whether r is a whole number of steps from the scale's
+// minimum, allowing for rounding in the arithmetic that produced it.
+func (s Scale) onStep(r float64) bool {
+	steps := (r - s.Min) / s.Step
+	return math.Abs(steps-math.Round(steps)) < 1e-9
+}
+
+// Check reports whether a rating is on the scale.
+func (s Scale) Check(r models.Rating) error {
+	if float64(r) < s.Min || float64(r) > s.Max || !s.onStep(float64(r)) {
+		if s.Step == 1 {
+			return fmt.Errorf("rating %s is not a whole number from %s to %s", FormatNumber(r), FormatNumber(models.Rating(s.Min)), FormatNumber(models.Rating(s.Max)))
+		}
+		return fmt.Errorf("rating %s is not from %s to %s in steps of %s", FormatNumber(r),
+			FormatNumber(models.Rating(s.Min)), FormatNumber(models.Rating(s.Max)), FormatNumber(models.Rating(s.Step)))
+	}
+	return nil
+}
+
+// Parse reads a rating typed on the scale, e.g. "4.5" or "85".
+func (s Scale) Parse(text string) (models.Rating, error) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("enter a rating from %s", s.Range())
+	}
+	r := models.Rating(n)
+	if err := s.Check(r); err != nil {
+		return 0, err
+	}
+	return r, nil
+}
+
+// Range describes the ratings the scale allows, e.g. "1–5" or "0.5–5 in
+// half steps".
+func (s Scale) Range() string {
+	text := FormatNumber(models.Rating(s.Min)) + "–" + FormatNumber(models.Rating(s.Max))
+	if s.Step == 0.5 {
+		text += " in half steps"
+	}
+	return text
+}
+
+// Normalize maps a rating onto 0–1, as published for the site.
+func (s Scale) Normalize(r models.Rating) float64 {
+	return math.Round(float64(r)/s.Max*1000) / 1000
+}
+
+// Format renders a rating with its scale, e.g. "4.5/5" or "85/100". An unset
+// rating renders as "–".
+func (s Scale) Format(r models.Rating) string {
+	if r == 0 {
+		return "–"
+	}
+	return FormatNumber(r) + "/" + FormatNumber(models.Rating(s.Max))
+}
+
+// FormatNumber renders a rating without a scale, e.g. "4.5".
+func FormatNumber(r models.Rating) string {
+	return strconv.FormatFloat(float64(r), 'f', -1, 64)
+}
+
+// Convert moves a rating from one scale to another. It fails rather than round
+// when the rating has no exact equivalent on the target scale, so converting
+// back always gives the original rating. An unset rating stays unset.
+func Convert(r models.Rating, from, to Scale) (models.Rating, error) {
+	if r == 0 {
+		return 0, nil
+	}
+	converted := float64(r) / from.Max * to.Max
+	if !to.onStep(converted) || converted < to.Min || converted > to.Max {
+		return 0, fmt.Errorf("%s has no exact equivalent on the %s scale", from.Format(r), to.Name)
+	}
+	return models.Rating(math.Round(converted/to.Step) * to.Step), nil
+}
+
+// checkRating validates an entry's rating, allowing it to be unset when
+// optional is true.
+func checkRating(s Scale, r models.Rating, optional bool) error {
+	if r == 0 && optional {
+		return nil
+	}
+	return s.Check(r)
+}
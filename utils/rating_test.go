@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"testing"
+	"utilodactyl/models"
+)
+
+func mustScale(t *testing.T, name models.RatingScale) Scale {
+	t.Helper()
+	s, err := FindScale(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestScaleCheck(t *testing.T) {
+	tests := []struct {
+		scale models.RatingScale
+		r     models.Rating
+		ok    bool
+	}{
+		{models.ScaleStars, 1, true},
+		{models.ScaleStars, 5, true},
+		{models.ScaleStars, 0, false},
+		{models.ScaleStars, 4.5, false},
+		{models.ScaleStars, 6, false},
+		{models.ScaleHalfStars, 0.5, true},
+		{models.ScaleHalfStars, 4.5, true},
+		{models.ScaleHalfStars, 4.25, false},
+		{models.ScaleHalfStars, 5.5, false},
+		{models.ScaleTen, 10, true},
+		{models.ScaleTen, 0.5, false},
+		{models.ScaleHundred, 85, true},
+		{models.ScaleHundred, 100.5, false},
+	}
+
+	for _, tt := range tests {
+		err := mustScale(t, tt.scale).Check(tt.r)
+		if (err == nil) != tt.ok {
+			t.Errorf("scale %s: Check(%v) = %v, want ok=%v", tt.scale, tt.r, err, tt.ok)
+		}
+	}
+}
+
+func TestScaleNormalize(t *testing.T) {
+	tests := []struct {
+		scale models.RatingScale
+		r     models.Rating
+		want  float64
+	}{
+		{models.ScaleStars, 5, 1},
+		{models.ScaleStars, 4, 0.8},
+		{models.ScaleHalfStars, 3.5, 0.7},
+		{models.ScaleTen, 7, 0.7},
+		{models.ScaleHundred, 85, 0.85},
+		// Rounded to three places.
+		{models.ScaleStars, 1, 0.2},
+		{models.ScaleHundred, 1, 0.01},
+	}
+
+	for _, tt := range tests {
+		if got := mustScale(t, tt.scale).Normalize(tt.r); got != tt.want {
+			t.Errorf("scale %s: Normalize(%v) = %v, want %v", tt.scale, tt.r, got, tt.want)
+		}
+	}
+
+	// Equal ratings on different scales normalize alike.
+	if a, b := mustScale(t, models.ScaleStars).Normalize(3), mustScale(t, models.ScaleHundred).Normalize(60); a != b {
+		t.Errorf("3/5 normalizes to %v but 60/100 to %v", a, b)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		from, to models.RatingScale
+		r        models.Rating
+		want     models.Rating
+		ok       bool
+	}{
+		{models.ScaleStars, models.ScaleTen, 4, 8, true},
+		{models.ScaleStars, models.ScaleHundred, 3, 60, true},
+		{models.ScaleStars, models.ScaleHalfStars, 5, 5, true},
+		{models.ScaleHalfStars, models.ScaleTen, 3.5, 7, true},
+		{models.ScaleHalfStars, models.ScaleHundred, 0.5, 10, true},
+		{models.ScaleTen, models.ScaleStars, 8, 4, true},
+		{models.ScaleHundred, models.ScaleTen, 70, 7, true},
+		// No exact equivalent.
+		{models.ScaleHalfStars, models.ScaleStars, 3.5, 0, false},
+		{models.ScaleTen, models.ScaleStars, 7, 0, false},
+		{models.ScaleHundred, models.ScaleTen, 85, 0, false},
+		{models.ScaleHundred, models.ScaleHalfStars, 15, 0, false},
+		// Unset stays unset.
+		{models.ScaleStars, models.ScaleHundred, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := Convert(tt.r, mustScale(t, tt.from), mustScale(t, tt.to))
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("Convert(%v, %s, %s) = %v, %v; want %v, ok=%v", tt.r, tt.from, tt.to, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestConvertRoundTrip converts every rating of every scale to every other
+// scale and back, checking that whatever converts comes back unchanged.
+func TestConvertRoundTrip(t *testing.T) {
+	for _, from := range Scales {
+		for _, to := range Scales {
+			for _, r := range from.Ratings() {
+				converted, err := Convert(r, from, to)
+				if err != nil {
+					continue
+				}
+				if err := to.Check(converted); err != nil {
+					t.Errorf("%s → %s: %v converted to %v, off the target scale: %v", from.Name, to.Name, r, converted, err)
+					continue
+				}
+				back, err := Convert(converted, to, from)
+				if err != nil || back != r {
+					t.Errorf("%s → %s → %s: %v came back as %v, %v", from.Name, to.Name, from.Name, r, back, err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"utilodactyl/models"
+)
+
+// PublishAtLayout is how scheduled publish times are entered and shown, in
+// local time.
+const PublishAtLayout = "2006-01-02 15:04"
+
+// publishedFile records when each collection was last published, so
+// publish-due can tell which scheduled entries have come due since.
+var publishedFile = filepath.Join(exportDir, "published.json")
+
+// ParsePublishAt reads a publish time in PublishAtLayout, or a date alone for
+// midnight. Empty text means no schedule.
+func ParsePublishAt(text string) (*time.Time, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{PublishAtLayout, SessionDateLayout} {
+		if t, err := time.ParseInLocation(layout, text, time.Local); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("enter a time like %s, or a date alone", time.Now().Format(PublishAtLayout))
+}
+
+// FormatPublishAt renders a publish time in local time, or "" if unset.
+func FormatPublishAt(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Local().Format(PublishAtLayout)
+}
+
+// Unlisted reports whether an entry is kept out of the public release at time
+// at: because it is private, a draft, or scheduled for later.
+func Unlisted(private, draft bool, publishAt *time.Time, at time.Time) bool {
+	return private || draft || (publishAt != nil && publishAt.After(at))
+}
+
+func BookUnlisted(b models.Book, at time.Time) bool {
+	return Unlisted(b.Private, b.Draft, b.PublishAt, at)
+}
+
+func GameUnlisted(g models.Game, at time.Time) bool {
+	return Unlisted(g.Private, g.Draft, g.PublishAt, at)
+}
+
+func ProjectUnlisted(p models.Project, at time.Time) bool {
+	return Unlisted(p.Private, p.Draft, p.PublishAt, at)
+}
+
+// ReviewUnlisted reports whether a review is a draft or scheduled for later at
+// time at. Reviews of excluded books are kept out too; see ExcludedBookIDs.
+func ReviewUnlisted(r models.Review, at time.Time) bool {
+	return Unlisted(false, r.Draft, r.PublishAt, at)
+}
+
+// ExcludedBookIDs returns the IDs of the books kept out of the public release
+// at time at: those unlisted and those with content warnings beyond limit, the
+// books' maxWarning. Their reviews and arcs are kept out with them.
+func ExcludedBookIDs(books []models.Book, limit models.Severity, at time.Time) map[uint32]bool {
+	excluded := make(map[uint32]bool)
+	for _, b := range books {
+		if BookUnlisted(b, at) || ExceedsWarning(b.ContentWarnings, limit) {
+			excluded[b.ID] = true
+		}
+	}
+	return excluded
+}
+
+func loadPublished() (map[string]time.Time, error) {
+	published := map[string]time.Time{}
+	data, err := os.ReadFile(publishedFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return published, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", publishedFile, err)
+	}
+	if err := json.Unmarshal(data, &published); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", publishedFile, err)
+	}
+	return published, nil
+}
+
+// recordPublished notes that fileName was published with every entry due at
+// time at.
+func recordPublished(fileName string, at time.Time) error {
+	published, err := loadPublished()
+	if err != nil {
+		return err
+	}
+	published[fileName] = at.UTC().Truncate(time.Second)
+	data, err := json.MarshalIndent(published, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", publishedFile, err)
+	}
+	if err := os.MkdirAll(exportDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", exportDir, err)
+	}
+	if err := os.WriteFile(publishedFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", publishedFile, err)
+	}
+	return nil
+}
+
+// DueCollections returns the collection files holding entries that were
+// scheduled to go public after the collection was last published and are due
+// at time at. A book coming due brings its reviews and arcs along, and a
+// review coming due the statistics of its arc.
+func DueCollections(at time.Time) ([]string, error) {
+	published, err := loadPublished()
+	if err != nil {
+		return nil, err
+	}
+	comesDue := func(fileName string, publishAt *time.Time) bool {
+		return publishAt != nil && publishAt.After(published[fileName]) && !publishAt.After(at)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	books, err := LoadBooks()
+	if err != nil {
+		return nil, err
+	}
+	games, err := LoadGames()
+	if err != nil {
+		return nil, err
+	}
+	projects, err := LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := LoadReviews()
+	if err != nil {
+		return nil, err
+	}
+	arcs, err := LoadArcs()
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := ExcludedBookIDs(books, cfg.Books.MaxWarning, at)
+	due := make(map[string]bool)
+	for _, b := range books {
+		if excluded[b.ID] {
+			continue
+		}
+		due[booksFile] = due[booksFile] || comesDue(booksFile, b.PublishAt)
+		if slices.ContainsFunc(reviews, func(r models.Review) bool { return r.BookID == b.ID }) {
+			due[reviewsFile] = due[reviewsFile] || comesDue(reviewsFile, b.PublishAt)
+		}
+		if slices.ContainsFunc(arcs, func(a models.Arc) bool { return a.BookID == b.ID }) {
+			due[ArcsFile] = due[ArcsFile] || comesDue(ArcsFile, b.PublishAt)
+		}
+	}
+	for _, g := range games {
+		if !GameUnlisted(g, at) && !ExceedsWarning(g.ContentWarnings, cfg.Games.MaxWarning) {
+			due[gamesFile] = due[gamesFile] || comesDue(gamesFile, g.PublishAt)
+		}
+	}
+	for _, p := range projects {
+		if !ProjectUnlisted(p, at) {
+			due[projectsFile] = due[projectsFile] || comesDue(projectsFile, p.PublishAt)
+		}
+	}
+	for _, r := range reviews {
+		if excluded[r.BookID] || ReviewUnlisted(r, at) {
+			continue
+		}
+		due[reviewsFile] = due[reviewsFile] || comesDue(reviewsFile, r.PublishAt)
+		if _, ok := FindArc(arcs, r); ok {
+			due[ArcsFile] = due[ArcsFile] || comesDue(ArcsFile, r.PublishAt)
+		}
+	}
+
+	var files []string
+	for _, f := range []string{booksFile, gamesFile, projectsFile, reviewsFile, ArcsFile} {
+		if due[f] {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
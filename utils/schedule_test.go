@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDueCollections(t *testing.T) {
+	published := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	at := published.Add(2 * time.Hour)
+	due := published.Add(time.Hour).Format(time.RFC3339)
+	later := at.Add(time.Hour).Format(time.RFC3339)
+	earlier := published.Add(-time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{"nothing scheduled", map[string]string{
+			"books.json": `[{"id":1,"title":"Dune"}]`,
+		}, nil},
+		{"book due", map[string]string{
+			"books.json": `[{"id":1,"title":"Dune","publishAt":"` + due + `"}]`,
+		}, []string{"books.json"}},
+		{"book due with its reviews and arcs", map[string]string{
+			"books.json":   `[{"id":1,"title":"Dune","publishAt":"` + due + `"},{"id":2,"title":"Emma"}]`,
+			"reviews.json": `[{"id":1,"bookId":1,"chapter":1}]`,
+			ArcsFile:       `[{"id":1,"bookId":1,"name":"Arrakis","first":{"chapter":1},"last":{"chapter":3}}]`,
+		}, []string{"books.json", "reviews.json", ArcsFile}},
+		{"reviews of other books stay put", map[string]string{
+			"books.json":   `[{"id":1,"title":"Dune","publishAt":"` + due + `"},{"id":2,"title":"Emma"}]`,
+			"reviews.json": `[{"id":1,"bookId":2,"chapter":1}]`,
+		}, []string{"books.json"}},
+		{"book not yet due", map[string]string{
+			"books.json": `[{"id":1,"title":"Dune","publishAt":"` + later + `"}]`,
+		}, nil},
+		{"book already published", map[string]string{
+			"books.json": `[{"id":1,"title":"Dune","publishAt":"` + earlier + `"}]`,
+		}, nil},
+		{"draft book", map[string]string{
+			"books.json": `[{"id":1,"title":"Dune","draft":true,"publishAt":"` + due + `"}]`,
+		}, nil},
+		{"private book", map[string]string{
+			"books.json": `[{"id":1,"title":"Dune","private":true,"publishAt":"` + due + `"}]`,
+		}, nil},
+		{"book over maxWarning", map[string]string{
+			ConfigFile:     `{"books":{"maxWarning":"mild"}}`,
+			"books.json":   `[{"id":1,"title":"Berserk","publishAt":"` + due + `","contentWarnings":[{"category":"violence","severity":"severe"}]}]`,
+			"reviews.json": `[{"id":1,"bookId":1,"chapter":1}]`,
+		}, nil},
+		{"game due", map[string]string{
+			"games.json": `[{"id":1,"title":"Hades","publishAt":"` + due + `"}]`,
+		}, []string{"games.json"}},
+		{"game over maxWarning", map[string]string{
+			ConfigFile:   `{"games":{"maxWarning":"moderate"}}`,
+			"games.json": `[{"id":1,"title":"Doom","publishAt":"` + due + `","contentWarnings":[{"category":"violence","severity":"severe"}]}]`,
+		}, nil},
+		{"project due", map[string]string{
+			"projects.json": `[{"id":1,"name":"utilodactyl","publishAt":"` + due + `"}]`,
+		}, []string{"projects.json"}},
+		{"draft project", map[string]string{
+			"projects.json": `[{"id":1,"name":"utilodactyl","draft":true,"publishAt":"` + due + `"}]`,
+		}, nil},
+		{"review due in an arc", map[string]string{
+			"books.json":   `[{"id":1,"title":"Dune"}]`,
+			"reviews.json": `[{"id":1,"bookId":1,"chapter":2,"publishAt":"` + due + `"}]`,
+			ArcsFile:       `[{"id":1,"bookId":1,"name":"Arrakis","first":{"chapter":1},"last":{"chapter":3}}]`,
+		}, []string{"reviews.json", ArcsFile}},
+		{"review due outside any arc", map[string]string{
+			"books.json":   `[{"id":1,"title":"Dune"}]`,
+			"reviews.json": `[{"id":1,"bookId":1,"chapter":9,"publishAt":"` + due + `"}]`,
+			ArcsFile:       `[{"id":1,"bookId":1,"name":"Arrakis","first":{"chapter":1},"last":{"chapter":3}}]`,
+		}, []string{"reviews.json"}},
+		{"review due of an unlisted book", map[string]string{
+			"books.json":   `[{"id":1,"title":"Dune","draft":true}]`,
+			"reviews.json": `[{"id":1,"bookId":1,"chapter":1,"publishAt":"` + due + `"}]`,
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t)
+			for name, content := range tt.files {
+				if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			last := map[string]time.Time{}
+			for _, f := range []string{"books.json", "games.json", "projects.json", "reviews.json", ArcsFile} {
+				last[f] = published
+			}
+			data, _ := json.Marshal(last)
+			if err := os.Mkdir(exportDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(publishedFile, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := DueCollections(at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("DueCollections = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDueCollectionsNeverPublished treats a collection that was never
+// published as last published at the zero time.
+func TestDueCollectionsNeverPublished(t *testing.T) {
+	chdirTemp(t)
+	publishAt := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	books := `[{"id":1,"title":"Dune","publishAt":"` + publishAt.Format(time.RFC3339) + `"}]`
+	if err := os.WriteFile("books.json", []byte(books), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := DueCollections(publishAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, []string{"books.json"}) {
+		t.Errorf("DueCollections = %q, want [books.json]", got)
+	}
+}
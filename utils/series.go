@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"utilodactyl/models"
+)
+
+// SeriesFile holds the book series. Books refer to them by ID, and the
+// published books carry the series name.
+const SeriesFile = "series.json"
+
+func LoadSeries() ([]models.Series, error) {
+	return readJSONFile[models.Series](SeriesFile)
+}
+
+func SaveSeries(series []models.Series) error {
+	return writeJSONFile(SeriesFile, series)
+}
+
+func GenerateSeriesID() (uint32, error) {
+	return generateNextID(LoadSeries, func(s models.Series) uint32 { return s.ID })
+}
+
+// FindSeries returns the series with the given ID.
+func FindSeries(series []models.Series, id uint32) (models.Series, bool) {
+	for _, s := range series {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return models.Series{}, false
+}
+
+// SeriesEntries returns the books of a series in reading order.
+func SeriesEntries(books []models.Book, id uint32) []models.Book {
+	indices := SeriesIndices(books, id)
+	entries := make([]models.Book, len(indices))
+	for i, j := range indices {
+		entries[i] = books[j]
+	}
+	return entries
+}
+
+// SeriesIndices returns the indices in books of the books of a series, in
+// reading order, for callers that edit them in place.
+func SeriesIndices(books []models.Book, id uint32) []int {
+	var indices []int
+	for i, b := range books {
+		if b.SeriesID == id {
+			indices = append(indices, i)
+		}
+	}
+	slices.SortStableFunc(indices, func(i, j int) int { return compareSeriesPosition(books[i], books[j]) })
+	return indices
+}
+
+func compareSeriesPosition(a, b models.Book) int {
+	return cmp.Compare(a.SeriesPosition, b.SeriesPosition)
+}
+
+// GroupBySeries orders books so the entries of each series follow each other
+// in reading order, series sorted by name, with books outside any series
+// last in their original order.
+func GroupBySeries(books []models.Book, series []models.Series) []models.Book {
+	names := make(map[uint32]string, len(series))
+	for _, s := range series {
+		names[s.ID] = s.Name
+	}
+
+	grouped := slices.Clone(books)
+	slices.SortStableFunc(grouped, func(a, b models.Book) int {
+		_, inA := names[a.SeriesID]
+		_, inB := names[b.SeriesID]
+		switch {
+		case inA != inB:
+			if inA {
+				return -1
+			}
+			return 1
+		case !inA:
+			return 0
+		}
+		if c := cmp.Compare(strings.ToLower(names[a.SeriesID]), strings.ToLower(names[b.SeriesID])); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.SeriesID, b.SeriesID); c != 0 {
+			return c
+		}
+		return compareSeriesPosition(a, b)
+	})
+	return grouped
+}
+
+// NextPosition is the first whole position after the last entry of a series.
+func NextPosition(books []models.Book, id uint32) float64 {
+	var last float64
+	for _, b := range books {
+		if b.SeriesID == id {
+			last = max(last, b.SeriesPosition)
+		}
+	}
+	return float64(int(last) + 1)
+}
+
+// NextUnread returns, for each series, its first entry still in the planning
+// status, the first one of the workflow ("Plan to Read" by default), in the
+// order of the series list.
+func NextUnread(books []models.Book, series []models.Series, statuses []models.StatusConfig) []models.Book {
+	if len(statuses) == 0 {
+		return nil
+	}
+	planned := statuses[0].Name
+
+	var next []models.Book
+	for _, s := range series {
+		for _, b := range SeriesEntries(books, s.ID) {
+			if b.Status == planned {
+				next = append(next, b)
+				break
+			}
+		}
+	}
+	return next
+}
+
+// FormatSeriesPosition renders a position without trailing zeros, e.g. "2"
+// or "2.5".
+func FormatSeriesPosition(position float64) string {
+	return strconv.FormatFloat(position, 'f', -1, 64)
+}
+
+// ParseSeriesPosition reads a position in a series, which must be positive.
+func ParseSeriesPosition(text string) (float64, error) {
+	position, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil || position <= 0 {
+		return 0, fmt.Errorf("enter a positive number, such as 3 or 2.5")
+	}
+	return position, nil
+}
+
+// SeriesLabel renders a book's place in its series, e.g. "Mistborn #2.5", or
+// "" if it is in none.
+func SeriesLabel(series []models.Series, b models.Book) string {
+	s, ok := FindSeries(series, b.SeriesID)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s #%s", s.Name, FormatSeriesPosition(b.SeriesPosition))
+}
+
+// ValidateSeries checks series for duplicate IDs and empty or repeated names.
+func ValidateSeries(series []models.Series) error {
+	var errs []error
+	ids := make(map[uint32]bool, len(series))
+	names := make(map[string]bool, len(series))
+	for i, s := range series {
+		if ids[s.ID] {
+			errs = append(errs, fmt.Errorf("series #%d: duplicate id %d", i+1, s.ID))
+		}
+		ids[s.ID] = true
+		name := strings.ToLower(strings.TrimSpace(s.Name))
+		if name == "" {
+			errs = append(errs, fmt.Errorf("series #%d (id %d): empty name", i+1, s.ID))
+		} else if names[name] {
+			errs = append(errs, fmt.Errorf("series #%d (id %d): name %q is used twice", i+1, s.ID, s.Name))
+		}
+		names[name] = true
+	}
+	return errors.Join(errs...)
+}
+
+func validateBookSeries(series []models.Series, b models.Book) error {
+	if b.SeriesID == 0 {
+		if b.SeriesPosition != 0 {
+			return fmt.Errorf("series position %s without a series", FormatSeriesPosition(b.SeriesPosition))
+		}
+		return nil
+	}
+	if _, ok := FindSeries(series, b.SeriesID); !ok {
+		return fmt.Errorf("unknown series %d", b.SeriesID)
+	}
+	if b.SeriesPosition < 0 {
+		return fmt.Errorf("negative series position %s", FormatSeriesPosition(b.SeriesPosition))
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+	"utilodactyl/models"
+)
+
+func TestSeriesIndices(t *testing.T) {
+	books := []models.Book{
+		{ID: 1, SeriesID: 7, SeriesPosition: 3},
+		{ID: 2, SeriesID: 8, SeriesPosition: 1},
+		{ID: 3, SeriesID: 7, SeriesPosition: 1},
+		{ID: 4, SeriesID: 7, SeriesPosition: 2.5},
+		{ID: 5},
+		{ID: 6, SeriesID: 7, SeriesPosition: 1},
+	}
+	tests := []struct {
+		series uint32
+		want   []int
+	}{
+		// Ties keep the order of the collection.
+		{7, []int{2, 5, 3, 0}},
+		{8, []int{1}},
+		{9, nil},
+	}
+
+	for _, tt := range tests {
+		got := SeriesIndices(books, tt.series)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("SeriesIndices(%d) = %v, want %v", tt.series, got, tt.want)
+		}
+		var wantIDs []uint32
+		for _, i := range tt.want {
+			wantIDs = append(wantIDs, books[i].ID)
+		}
+		var gotIDs []uint32
+		for _, b := range SeriesEntries(books, tt.series) {
+			gotIDs = append(gotIDs, b.ID)
+		}
+		if !slices.Equal(gotIDs, wantIDs) {
+			t.Errorf("SeriesEntries(%d) = books %v, want %v", tt.series, gotIDs, wantIDs)
+		}
+	}
+}
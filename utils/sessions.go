@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"utilodactyl/models"
+)
+
+// SessionDateLayout is how play session dates are written.
+const SessionDateLayout = "2006-01-02"
+
+// Today is the current local date in SessionDateLayout.
+func Today() string {
+	return time.Now().Format(SessionDateLayout)
+}
+
+// Playtime is the total number of minutes logged for a game.
+func Playtime(g models.Game) uint32 {
+	var total uint32
+	for _, s := range g.Sessions {
+		total += s.Minutes
+	}
+	return total
+}
+
+// LastPlayed is the date of the latest session logged for a game, or "" if
+// none was.
+func LastPlayed(g models.Game) string {
+	last := ""
+	for _, s := range g.Sessions {
+		// Dates in SessionDateLayout sort as strings.
+		if s.Date > last {
+			last = s.Date
+		}
+	}
+	return last
+}
+
+// FormatMinutes renders a duration in minutes, e.g. "45m" or "12h 05m".
+func FormatMinutes(minutes uint32) string {
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	default:
+		return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+	}
+}
+
+// ParseMinutes reads a session length, either as plain minutes ("90"), hours
+// and minutes ("1:30") or a duration ("1h30m", "45m").
+func ParseMinutes(text string) (uint32, error) {
+	text = strings.TrimSpace(text)
+	if n, err := strconv.ParseUint(text, 10, 32); err == nil {
+		return uint32(n), nil
+	}
+	if h, m, ok := strings.Cut(text, ":"); ok {
+		hours, errH := strconv.ParseUint(h, 10, 32)
+		mins, errM := strconv.ParseUint(m, 10, 32)
+		if errH == nil && errM == nil && mins < 60 {
+			return uint32(hours*60 + mins), nil
+		}
+	}
+	if d, err := time.ParseDuration(strings.ReplaceAll(text, " ", "")); err == nil && d >= 0 {
+		return uint32(d.Round(time.Minute) / time.Minute), nil
+	}
+	return 0, fmt.Errorf("enter minutes (90), hours and minutes (1:30) or a duration (1h30m)")
+}
+
+func validateSessions(sessions []models.PlaySession) error {
+	for i, s := range sessions {
+		if _, err := time.Parse(SessionDateLayout, s.Date); err != nil {
+			return fmt.Errorf("session %d: invalid date %q", i+1, s.Date)
+		}
+		if s.Percent != nil && *s.Percent > 100 {
+			return fmt.Errorf("session %d: percent %d is over 100", i+1, *s.Percent)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"utilodactyl/models"
+)
+
+// DefaultBookStatuses is the reading workflow used unless utilodactyl.json
+// configures another.
+var DefaultBookStatuses = []models.StatusConfig{
+	{Name: "Plan to Read", Next: []models.Status{"Reading", "Finished", "Dropped"}},
+	{Name: "Reading", Next: []models.Status{"Finished", "Dropped", "Plan to Read"}},
+	{Name: "Finished", Next: []models.Status{"Reading"}, Complete: true},
+	{Name: "Dropped", Next: []models.Status{"Reading", "Plan to Read"}, AskReason: true},
+}
+
+// DefaultGameStatuses is the play workflow used unless utilodactyl.json
+// configures another.
+var DefaultGameStatuses = []models.StatusConfig{
+	{Name: "Plan to Play", Next: []models.Status{"Playing", "Finished", "Dropped"}},
+	{Name: "Playing", Next: []models.Status{"Finished", "Dropped", "Plan to Play"}},
+	{Name: "Finished", Next: []models.Status{"Playing"}, Complete: true},
+	{Name: "Dropped", Next: []models.Status{"Playing", "Plan to Play"}, AskReason: true},
+}
+
+// BookStatuses returns the configured book statuses.
+func BookStatuses() ([]models.StatusConfig, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return statusesOrDefault(cfg.Books.Statuses, DefaultBookStatuses)
+}
+
+// GameStatuses returns the configured game statuses.
+func GameStatuses() ([]models.StatusConfig, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return statusesOrDefault(cfg.Games.Statuses, DefaultGameStatuses)
+}
+
+func statusesOrDefault(configured, defaults []models.StatusConfig) ([]models.StatusConfig, error) {
+	if len(configured) == 0 {
+		return defaults, nil
+	}
+	if err := validateStatusConfig(configured); err != nil {
+		return nil, fmt.Errorf("invalid statuses in %s: %w", ConfigFile, err)
+	}
+	return configured, nil
+}
+
+func validateStatusConfig(statuses []models.StatusConfig) error {
+	var errs []error
+	seen := make(map[models.Status]bool, len(statuses))
+	for _, s := range statuses {
+		if s.Name == "" {
+			errs = append(errs, errors.New("status with empty name"))
+		}
+		if seen[s.Name] {
+			errs = append(errs, fmt.Errorf("duplicate status %q", s.Name))
+		}
+		seen[s.Name] = true
+	}
+	for _, s := range statuses {
+		for _, next := range s.Next {
+			if !seen[next] {
+				errs = append(errs, fmt.Errorf("status %q moves to unknown status %q", s.Name, next))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FindStatus returns the definition of status.
+func FindStatus(statuses []models.StatusConfig, status models.Status) (models.StatusConfig, bool) {
+	i := slices.IndexFunc(statuses, func(s models.StatusConfig) bool { return s.Name == status })
+	if i < 0 {
+		return models.StatusConfig{}, false
+	}
+	return statuses[i], true
+}
+
+// NextStatuses returns the statuses an entry in status current may move to,
+// starting with current itself. New entries (and entries whose status is no
+// longer configured) may take any status.
+func NextStatuses(statuses []models.StatusConfig, current models.Status) []models.Status {
+	all := make([]models.Status, len(statuses))
+	for i, s := range statuses {
+		all[i] = s.Name
+	}
+
+	def, ok := FindStatus(statuses, current)
+	if !ok {
+		return all
+	}
+	return append([]models.Status{current}, def.Next...)
+}
+
+// checkTransition reports whether an entry may move from one status to
+// another.
+func checkTransition(statuses []models.StatusConfig, from, to models.Status) (models.StatusConfig, error) {
+	def, ok := FindStatus(statuses, to)
+	if !ok {
+		return def, fmt.Errorf("unknown status %q", to)
+	}
+	if !slices.Contains(NextStatuses(statuses, from), to) {
+		return def, fmt.Errorf("cannot move from %q to %q", from, to)
+	}
+	return def, nil
+}
+
+// ChangeBookStatus moves a book to a status, recording it in the history with
+// an optional note and applying the status's side effects: completing a book
+// stamps its finish date and moves its position to the end.
+func ChangeBookStatus(statuses []models.StatusConfig, b *models.Book, to models.Status, note string) error {
+	if to == b.Status {
+		return nil
+	}
+	def, err := checkTransition(statuses, b.Status, to)
+	if err != nil {
+		return err
+	}
+
+	seedStatus(&b.StatusHistory, b.Status, b.AddedAt)
+	b.Status = to
+	RecordStatus(&b.StatusHistory, to, note)
+	if def.Complete {
+		t := now()
+		b.FinishedAt = &t
+		b.Position = b.Length
+	}
+	return nil
+}
+
+// ChangeGameStatus moves a game to a status like ChangeBookStatus; completing
+// a game also sets its progress to 100%.
+func ChangeGameStatus(statuses []models.StatusConfig, g *models.Game, to models.Status, note string) error {
+	if to == g.Status {
+		return nil
+	}
+	def, err := checkTransition(statuses, g.Status, to)
+	if err != nil {
+		return err
+	}
+
+	seedStatus(&g.StatusHistory, g.Status, g.AddedAt)
+	g.Status = to
+	RecordStatus(&g.StatusHistory, to, note)
+	if def.Complete {
+		t := now()
+		g.FinishedAt = &t
+		g.Percent = 100
+	}
+	return nil
+}
+
+// validateStatus reports statuses the collection does not define.
+func validateStatus(statuses []models.StatusConfig, status models.Status) error {
+	if _, ok := FindStatus(statuses, status); !ok {
+		return fmt.Errorf("unknown status %q", status)
+	}
+	return nil
+}
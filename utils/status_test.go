@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+	"time"
+	"utilodactyl/models"
+)
+
+func TestNextStatuses(t *testing.T) {
+	all := []models.Status{"Plan to Read", "Reading", "Finished", "Dropped"}
+	tests := []struct {
+		current models.Status
+		want    []models.Status
+	}{
+		{"Plan to Read", []models.Status{"Plan to Read", "Reading", "Finished", "Dropped"}},
+		{"Reading", []models.Status{"Reading", "Finished", "Dropped", "Plan to Read"}},
+		{"Finished", []models.Status{"Finished", "Reading"}},
+		{"Dropped", []models.Status{"Dropped", "Reading", "Plan to Read"}},
+		// New entries and entries in a status no longer configured may take
+		// any status.
+		{"", all},
+		{"On Hold", all},
+	}
+
+	for _, tt := range tests {
+		if got := NextStatuses(DefaultBookStatuses, tt.current); !slices.Equal(got, tt.want) {
+			t.Errorf("NextStatuses(%q) = %q, want %q", tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		from, to     models.Status
+		ok           bool
+		wantComplete bool
+	}{
+		{"Plan to Read", "Reading", true, false},
+		{"Reading", "Finished", true, true},
+		{"Finished", "Reading", true, false},
+		{"Dropped", "Plan to Read", true, false},
+		{"", "Finished", true, true},
+		{"On Hold", "Dropped", true, false},
+		// Staying put is always allowed.
+		{"Finished", "Finished", true, true},
+		// Transitions the workflow does not allow.
+		{"Finished", "Dropped", false, false},
+		{"Dropped", "Finished", false, false},
+		// Unknown targets.
+		{"Reading", "On Hold", false, false},
+		{"", "", false, false},
+	}
+
+	for _, tt := range tests {
+		def, err := checkTransition(DefaultBookStatuses, tt.from, tt.to)
+		if (err == nil) != tt.ok {
+			t.Errorf("checkTransition(%q, %q) = %v, want ok=%v", tt.from, tt.to, err, tt.ok)
+			continue
+		}
+		if err == nil && def.Complete != tt.wantComplete {
+			t.Errorf("checkTransition(%q, %q) gave %+v, want complete=%v", tt.from, tt.to, def, tt.wantComplete)
+		}
+	}
+}
+
+func TestChangeBookStatusHistory(t *testing.T) {
+	added := time.Date(2026, 2, 11, 9, 0, 0, 0, time.UTC)
+	earlier := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		book      models.Book
+		to        models.Status
+		want      []models.Status
+		wantFirst time.Time // When the first status was entered.
+	}{
+		{"new book", models.Book{}, "Reading", []models.Status{"Reading"}, time.Time{}},
+		{"saved before histories, with an added time",
+			models.Book{Status: "Plan to Read", AddedAt: &added}, "Reading",
+			[]models.Status{"Plan to Read", "Reading"}, added},
+		{"saved before histories and timestamps",
+			models.Book{Status: "Plan to Read"}, "Reading",
+			[]models.Status{"Plan to Read", "Reading"}, time.Time{}},
+		{"history already kept",
+			models.Book{Status: "Reading", AddedAt: &added, StatusHistory: []models.StatusChange{{Status: "Reading", At: earlier}}}, "Finished",
+			[]models.Status{"Reading", "Finished"}, earlier},
+	}
+
+	for _, tt := range tests {
+		b := tt.book
+		if err := ChangeBookStatus(DefaultBookStatuses, &b, tt.to, ""); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		got := make([]models.Status, len(b.StatusHistory))
+		for i, change := range b.StatusHistory {
+			got[i] = change.Status
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: history = %q, want %q", tt.name, got, tt.want)
+			continue
+		}
+		if len(tt.want) > 1 && !b.StatusHistory[0].At.Equal(tt.wantFirst) {
+			t.Errorf("%s: first status at %v, want %v", tt.name, b.StatusHistory[0].At, tt.wantFirst)
+		}
+	}
+}
+
+func TestFormatStatusHistoryUnknownDate(t *testing.T) {
+	history := []models.StatusChange{{Status: "Plan to Read"}, {Status: "Dropped", At: time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local), Note: "lost interest"}}
+	want := "Plan to Read (unknown) → Dropped (2026-03-01: lost interest)"
+	if got := FormatStatusHistory(history); got != want {
+		t.Errorf("FormatStatusHistory = %q, want %q", got, want)
+	}
+}
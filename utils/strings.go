@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// MergeStrings combines string lists into one, in order, trimming whitespace,
+// dropping blanks and keeping only the first spelling of values that differ
+// only in case.
+func MergeStrings(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	merged := []string{}
+	for _, list := range lists {
+		for _, s := range list {
+			s = strings.TrimSpace(s)
+			key := strings.ToLower(s)
+			if s == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, s)
+		}
+	}
+	return merged
+}
+
+// SortFold sorts strings alphabetically, ignoring case.
+func SortFold(s []string) {
+	sort.SliceStable(s, func(i, j int) bool {
+		return strings.ToLower(s[i]) < strings.ToLower(s[j])
+	})
+}
+
+// SplitList splits comma-separated input into its trimmed, non-empty parts.
+func SplitList(input string) []string {
+	var parts []string
+	for _, p := range strings.Split(input, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
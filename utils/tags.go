@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// TagCount is a tag (or genre) and the number of entries using it.
+type TagCount struct {
+	Name  string
+	Count int
+}
+
+// CountTags counts how many of the given tag lists contain each tag, sorted
+// alphabetically. Tags differing only in spelling are counted separately, so
+// that drift stays visible.
+func CountTags(lists [][]string) []TagCount {
+	counts := make(map[string]int)
+	for _, list := range lists {
+		seen := make(map[string]bool, len(list))
+		for _, tag := range list {
+			if !seen[tag] {
+				seen[tag] = true
+				counts[tag]++
+			}
+		}
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	SortFold(names)
+
+	result := make([]TagCount, len(names))
+	for i, name := range names {
+		result[i] = TagCount{Name: name, Count: counts[name]}
+	}
+	return result
+}
+
+// ReplaceTags returns a copy of list with every tag in from replaced by the
+// tags in to, in the position of the first one replaced. An empty to deletes
+// the tags, several split them. It also reports whether the list changed.
+func ReplaceTags(list, from, to []string) ([]string, bool) {
+	remove := make(map[string]bool, len(from))
+	for _, f := range from {
+		remove[f] = true
+	}
+
+	var replaced []string
+	changed, inserted := false, false
+	for _, tag := range list {
+		if !remove[tag] {
+			replaced = append(replaced, tag)
+			continue
+		}
+		changed = true
+		if !inserted {
+			replaced = append(replaced, to...)
+			inserted = true
+		}
+	}
+	replaced = MergeStrings(replaced)
+	if !changed || slices.Equal(replaced, list) {
+		return list, false
+	}
+	return replaced, true
+}
+
+// NearDuplicate is a pair of tags that are probably meant to be the same.
+type NearDuplicate struct {
+	A, B string
+}
+
+// FindNearDuplicates flags pairs of tags that are equal after case folding and
+// ignoring punctuation, or within a small edit distance of each other.
+func FindNearDuplicates(tags []string) []NearDuplicate {
+	keys := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = foldTag(tag)
+	}
+
+	var dups []NearDuplicate
+	for i := range tags {
+		for j := i + 1; j < len(tags); j++ {
+			a, b := keys[i], keys[j]
+			if a == b || editDistance(a, b) <= maxTagDistance(min(len(a), len(b))) {
+				dups = append(dups, NearDuplicate{A: tags[i], B: tags[j]})
+			}
+		}
+	}
+	return dups
+}
+
+// foldTag lowercases a tag and drops everything but letters and digits, so
+// "Sci-Fi", "sci fi" and "scifi" compare equal.
+func foldTag(tag string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(tag) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// maxTagDistance is how many edits still count as a typo for a tag of the
+// given length. Short tags need to match exactly, since "rpg" and "fps" are
+// not typos of each other.
+func maxTagDistance(length int) int {
+	switch {
+	case length < 5:
+		return 0
+	case length < 9:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReplaceTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		list        []string
+		from, to    []string
+		want        []string
+		wantChanged bool
+	}{
+		{"rename", []string{"fantasy", "scifi", "classic"}, []string{"scifi"}, []string{"sci-fi"},
+			[]string{"fantasy", "sci-fi", "classic"}, true},
+		{"split in place", []string{"fantasy", "sff", "classic"}, []string{"sff"}, []string{"sci-fi", "fantasy"},
+			[]string{"fantasy", "sci-fi", "classic"}, true},
+		{"split keeps position", []string{"classic", "sff", "dystopia"}, []string{"sff"}, []string{"sci-fi", "fantasy"},
+			[]string{"classic", "sci-fi", "fantasy", "dystopia"}, true},
+		{"delete", []string{"fantasy", "meh", "classic"}, []string{"meh"}, nil,
+			[]string{"fantasy", "classic"}, true},
+		{"delete the only tag", []string{"meh"}, []string{"meh"}, nil,
+			nil, true},
+		{"merge into an existing tag", []string{"Sci-Fi", "classic", "scifi"}, []string{"scifi"}, []string{"Sci-Fi"},
+			[]string{"Sci-Fi", "classic"}, true},
+		{"merge several at the first one's place", []string{"classic", "scifi", "fantasy", "sf"}, []string{"scifi", "sf"}, []string{"sci-fi"},
+			[]string{"classic", "sci-fi", "fantasy"}, true},
+		{"not in the list", []string{"fantasy", "classic"}, []string{"scifi"}, []string{"sci-fi"},
+			[]string{"fantasy", "classic"}, false},
+		{"renamed to itself", []string{"fantasy", "classic"}, []string{"fantasy"}, []string{"fantasy"},
+			[]string{"fantasy", "classic"}, false},
+	}
+
+	for _, tt := range tests {
+		got, changed := ReplaceTags(tt.list, tt.from, tt.to)
+		if !slices.Equal(got, tt.want) || changed != tt.wantChanged {
+			t.Errorf("%s: ReplaceTags(%q, %q, %q) = %q, %v; want %q, %v",
+				tt.name, tt.list, tt.from, tt.to, got, changed, tt.want, tt.wantChanged)
+		}
+	}
+}
+
+func TestFindNearDuplicates(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []NearDuplicate
+	}{
+		{"case", []string{"Fantasy", "fantasy"}, []NearDuplicate{{"Fantasy", "fantasy"}}},
+		{"punctuation and spaces", []string{"Sci-Fi", "sci fi", "scifi"},
+			[]NearDuplicate{{"Sci-Fi", "sci fi"}, {"Sci-Fi", "scifi"}, {"sci fi", "scifi"}}},
+		{"one edit in a medium tag", []string{"horror", "horor"}, []NearDuplicate{{"horror", "horor"}}},
+		{"two edits in a long tag", []string{"found family", "fond famly"}, []NearDuplicate{{"found family", "fond famly"}}},
+		{"two edits in a medium tag", []string{"horror", "hrorr"}, nil},
+		{"short tags must match exactly", []string{"rpg", "fps", "rpgs"}, nil},
+		{"distinct tags", []string{"fantasy", "mystery", "romance"}, nil},
+	}
+
+	for _, tt := range tests {
+		if got := FindNearDuplicates(tt.tags); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: FindNearDuplicates(%q) = %v, want %v", tt.name, tt.tags, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"utilodactyl/models"
+)
+
+// now is the time stamped on entries, truncated so the files stay readable.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// stampChanges compares items with the version saved at path, setting the
+// added time of entries that are new and the updated time of entries that
+// changed. Entries saved before timestamps existed keep no added time, since
+// it is unknown.
+func stampChanges[T any](path string, items []T, id func(*T) uint32, stamps func(*T) (added, updated **time.Time)) error {
+	saved, err := readJSONFile[T](path)
+	if err != nil {
+		return err
+	}
+
+	before := make(map[uint32][]byte, len(saved))
+	for i := range saved {
+		before[id(&saved[i])] = withoutStamps(saved[i], stamps)
+	}
+
+	t := now()
+	for i := range items {
+		added, updated := stamps(&items[i])
+		old, ok := before[id(&items[i])]
+		switch {
+		case !ok:
+			if *added == nil {
+				*added = &t
+			}
+			*updated = &t
+		case !bytes.Equal(old, withoutStamps(items[i], stamps)):
+			*updated = &t
+		}
+	}
+	return nil
+}
+
+// withoutStamps encodes an entry with its timestamps cleared, for comparison.
+func withoutStamps[T any](item T, stamps func(*T) (added, updated **time.Time)) []byte {
+	added, updated := stamps(&item)
+	*added, *updated = nil, nil
+	data, _ := json.Marshal(item)
+	return data
+}
+
+func bookStamps(b *models.Book) (**time.Time, **time.Time) { return &b.AddedAt, &b.UpdatedAt }
+
+func gameStamps(g *models.Game) (**time.Time, **time.Time) { return &g.AddedAt, &g.UpdatedAt }
+
+// RecordStatus appends status to history if it differs from the latest entry.
+func RecordStatus(history *[]models.StatusChange, status models.Status, note string) {
+	if n := len(*history); n > 0 && (*history)[n-1].Status == status {
+		return
+	}
+	*history = append(*history, models.StatusChange{Status: status, At: now(), Note: note})
+}
+
+// seedStatus starts an empty history with the status the entry already had,
+// so entries saved before histories were kept do not lose it on their first
+// change. It is dated by the entry's added time, or left unknown.
+func seedStatus(history *[]models.StatusChange, current models.Status, added *time.Time) {
+	if len(*history) > 0 || current == "" {
+		return
+	}
+	change := models.StatusChange{Status: current}
+	if added != nil {
+		change.At = *added
+	}
+	*history = append(*history, change)
+}
+
+// FormatDate renders an optional timestamp as a local date, or "" if unset.
+func FormatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Local().Format("2006-01-02")
+}
+
+// FormatStatusHistory renders a status history as a single line, e.g.
+// "Plan to Read (2026-02-11) → Reading (2026-03-01)". Changes of unknown date
+// show as "unknown".
+func FormatStatusHistory(history []models.StatusChange) string {
+	parts := make([]string, len(history))
+	for i, change := range history {
+		date := "unknown"
+		if !change.At.IsZero() {
+			date = FormatDate(&change.At)
+		}
+		parts[i] = fmt.Sprintf("%s (%s)", change.Status, date)
+		if change.Note != "" {
+			parts[i] = fmt.Sprintf("%s (%s: %s)", change.Status, date, change.Note)
+		}
+	}
+	return strings.Join(parts, " → ")
+}
@@ -7,11 +7,8 @@ import (
 	"net/url"
 	"os"
 	"regexp"
-	"sort"
 	"strings"
 	"utilodactyl/models"
-
-	"github.com/charmbracelet/huh"
 )
 
 const (
@@ -56,19 +53,53 @@ func LoadGames() ([]models.Game, error) {
 	return readJSONFile[models.Game](gamesFile)
 }
 
+// LoadProjects reads the projects, giving any written before projects had IDs
+// one in memory. The file is left alone; the IDs are kept by the next save or
+// by MigrateProjects.
 func LoadProjects() ([]models.Project, error) {
-	return readJSONFile[models.Project](projectsFile)
+	projects, err := readJSONFile[models.Project](projectsFile)
+	if err != nil {
+		return nil, err
+	}
+	backfillProjectIDs(projects)
+	return projects, nil
 }
 
+// LoadReviews reads the reviews, giving any written before reviews had IDs one
+// in memory. The file is left alone; the IDs are kept by the next save or by
+// MigrateReviews.
 func LoadReviews() ([]models.Review, error) {
-	return readJSONFile[models.Review](reviewsFile)
+	reviews, err := readJSONFile[models.Review](reviewsFile)
+	if err != nil {
+		return nil, err
+	}
+	backfillReviewIDs(reviews)
+	return reviews, nil
 }
 
+// SaveBooks writes the books, stamping the ones that are new or changed.
 func SaveBooks(books []models.Book) error {
+	if err := stampChanges(booksFile, books, func(b *models.Book) uint32 { return b.ID }, bookStamps); err != nil {
+		return err
+	}
 	return writeJSONFile(booksFile, books)
 }
 
 func SaveGames(games []models.Game) error {
+	if err := stampChanges(gamesFile, games, func(g *models.Game) uint32 { return g.ID }, gameStamps); err != nil {
+		return err
+	}
+	return writeJSONFile(gamesFile, games)
+}
+
+// RewriteBooks writes the books without stamping them, for migrations such as
+// a rescale that change how entries are stored rather than the entries.
+func RewriteBooks(books []models.Book) error {
+	return writeJSONFile(booksFile, books)
+}
+
+// RewriteGames writes the games without stamping them, like RewriteBooks.
+func RewriteGames(games []models.Game) error {
 	return writeJSONFile(gamesFile, games)
 }
 
@@ -103,22 +134,21 @@ func GenerateGameID() (uint32, error) {
 	return generateNextID(LoadGames, func(g models.Game) uint32 { return g.ID })
 }
 
+func GenerateProjectID() (uint32, error) {
+	return generateNextID(LoadProjects, func(p models.Project) uint32 { return p.ID })
+}
+
 func GenerateReviewID() (uint32, error) {
-	return generateNextID(LoadReviews, func(r models.Review) uint32 { return r.Chapter })
+	return generateNextID(LoadReviews, func(r models.Review) uint32 { return r.ID })
 }
 
 func collectUniqueStrings[T any](items []T, extract func(T) []string) []string {
-	unique := make(map[string]struct{})
-	for _, item := range items {
-		for _, s := range extract(item) {
-			unique[s] = struct{}{}
-		}
-	}
-	result := make([]string, 0, len(unique))
-	for s := range unique {
-		result = append(result, s)
+	lists := make([][]string, len(items))
+	for i, item := range items {
+		lists[i] = extract(item)
 	}
-	sort.Strings(result)
+	result := MergeStrings(lists...)
+	SortFold(result)
 	return result
 }
 
@@ -173,14 +203,3 @@ func ValidateColor(s string) error {
 
 	return nil
 }
-
-func GenPercentOpts() []huh.Option[uint32] {
-	options := make([]huh.Option[uint32], 0, 100)
-
-	for i := 1; i <= 100; i++ {
-		option := huh.NewOption(fmt.Sprintf("%d", i), uint32(i))
-		options = append(options, option)
-	}
-
-	return options
-}
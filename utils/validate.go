@@ -0,0 +1,222 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"utilodactyl/models"
+)
+
+// ValidateBooks checks a book collection for problems that would break the
+// edit flows or the site, such as duplicate IDs, missing titles or statuses
+// the collection does not define.
+func ValidateBooks(books []models.Book) error {
+	statuses, err := BookStatuses()
+	if err != nil {
+		return err
+	}
+	series, err := LoadSeries()
+	if err != nil {
+		return err
+	}
+	people, err := LoadPeople()
+	if err != nil {
+		return err
+	}
+	scale, err := BookScale()
+	if err != nil {
+		return err
+	}
+	taxonomy, err := BookWarnings()
+	if err != nil {
+		return err
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	errs := []error{ValidateSeries(series), ValidatePeople(people), checkPrivateConfig(BookPrivateFields, cfg.Books)}
+	seen := make(map[uint32]bool, len(books))
+	for i, b := range books {
+		if seen[b.ID] {
+			errs = append(errs, fmt.Errorf("book #%d: duplicate id %d", i+1, b.ID))
+		}
+		seen[b.ID] = true
+		if strings.TrimSpace(b.Title) == "" {
+			errs = append(errs, fmt.Errorf("book #%d (id %d): empty title", i+1, b.ID))
+		}
+		if err := validateStatus(statuses, b.Status); err != nil {
+			errs = append(errs, fmt.Errorf("book #%d (id %d): %w", i+1, b.ID, err))
+		}
+		if err := checkRating(scale, b.Rating, true); err != nil {
+			errs = append(errs, fmt.Errorf("book #%d (id %d): %w", i+1, b.ID, err))
+		}
+		if err := validateWarnings(taxonomy, b.ContentWarnings); err != nil {
+			errs = append(errs, fmt.Errorf("book #%d (id %d): %w", i+1, b.ID, err))
+		}
+		if err := validatePrivateFields(BookPrivateFields, b.PrivateFields); err != nil {
+			errs = append(errs, fmt.Errorf("book #%d (id %d): %w", i+1, b.ID, err))
+		}
+		if err := validateBookProgress(b); err != nil {
+			errs = append(errs, fmt.Errorf("book #%d (id %d): %w", i+1, b.ID, err))
+		}
+		if err := validateBookSeries(series, b); err != nil {
+			errs = append(errs, fmt.Errorf("book #%d (id %d): %w", i+1, b.ID, err))
+		}
+		if err := validateContributors(people, b.Contributors); err != nil {
+			errs = append(errs, fmt.Errorf("book #%d (id %d): %w", i+1, b.ID, err))
+		}
+		errs = append(errs, validateLinks(fmt.Sprintf("book #%d (id %d)", i+1, b.ID), b.Links)...)
+	}
+	return errors.Join(errs...)
+}
+
+func ValidateGames(games []models.Game) error {
+	statuses, err := GameStatuses()
+	if err != nil {
+		return err
+	}
+	scale, err := GameScale()
+	if err != nil {
+		return err
+	}
+	taxonomy, err := GameWarnings()
+	if err != nil {
+		return err
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	errs := []error{checkPrivateConfig(GamePrivateFields, cfg.Games)}
+	seen := make(map[uint32]bool, len(games))
+	for i, g := range games {
+		if seen[g.ID] {
+			errs = append(errs, fmt.Errorf("game #%d: duplicate id %d", i+1, g.ID))
+		}
+		seen[g.ID] = true
+		if strings.TrimSpace(g.Title) == "" {
+			errs = append(errs, fmt.Errorf("game #%d (id %d): empty title", i+1, g.ID))
+		}
+		if g.Percent > 100 {
+			errs = append(errs, fmt.Errorf("game #%d (id %d): percent %d is over 100", i+1, g.ID, g.Percent))
+		}
+		if err := validateStatus(statuses, g.Status); err != nil {
+			errs = append(errs, fmt.Errorf("game #%d (id %d): %w", i+1, g.ID, err))
+		}
+		if err := checkRating(scale, g.Rating, true); err != nil {
+			errs = append(errs, fmt.Errorf("game #%d (id %d): %w", i+1, g.ID, err))
+		}
+		if err := validateWarnings(taxonomy, g.ContentWarnings); err != nil {
+			errs = append(errs, fmt.Errorf("game #%d (id %d): %w", i+1, g.ID, err))
+		}
+		if err := validatePrivateFields(GamePrivateFields, g.PrivateFields); err != nil {
+			errs = append(errs, fmt.Errorf("game #%d (id %d): %w", i+1, g.ID, err))
+		}
+		if err := validateSessions(g.Sessions); err != nil {
+			errs = append(errs, fmt.Errorf("game #%d (id %d): %w", i+1, g.ID, err))
+		}
+		errs = append(errs, validateLinks(fmt.Sprintf("game #%d (id %d)", i+1, g.ID), g.Links)...)
+	}
+	return errors.Join(errs...)
+}
+
+func ValidateProjects(projects []models.Project) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	errs := []error{checkPrivateConfig(ProjectPrivateFields, cfg.Projects)}
+	seen := make(map[uint32]bool, len(projects))
+	for i, p := range projects {
+		if seen[p.ID] {
+			errs = append(errs, fmt.Errorf("project #%d: duplicate id %d", i+1, p.ID))
+		}
+		seen[p.ID] = true
+		if strings.TrimSpace(p.Name) == "" {
+			errs = append(errs, fmt.Errorf("project #%d: empty name", i+1))
+		}
+		if err := validatePrivateFields(ProjectPrivateFields, p.PrivateFields); err != nil {
+			errs = append(errs, fmt.Errorf("project #%d (id %d): %w", i+1, p.ID, err))
+		}
+		errs = append(errs, validateLinks(fmt.Sprintf("project #%d (id %d)", i+1, p.ID), p.Links)...)
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateReviews checks reviews for duplicate IDs, chapters reviewed twice
+// for the same work, works that do not exist and ratings out of range.
+func ValidateReviews(reviews []models.Review) error {
+	books, err := LoadBooks()
+	if err != nil {
+		return err
+	}
+	works, err := LoadWorks()
+	if err != nil {
+		return err
+	}
+	arcs, err := LoadArcs()
+	if err != nil {
+		return err
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	scale, err := FindScale(cfg.Reviews.RatingScale)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{ValidateWorks(works), ValidateArcs(arcs, books, works), checkPrivateConfig(ReviewPrivateFields, cfg.Reviews)}
+	seen := make(map[uint32]bool, len(reviews))
+	type chapterKey struct {
+		work  WorkRef
+		point models.ChapterPoint
+	}
+	chapters := make(map[chapterKey]bool)
+	for i, r := range reviews {
+		if seen[r.ID] {
+			errs = append(errs, fmt.Errorf("review #%d: duplicate id %d", i+1, r.ID))
+		}
+		seen[r.ID] = true
+
+		ref := ReviewWork(r)
+		if r.BookID != 0 && r.WorkID != 0 {
+			errs = append(errs, fmt.Errorf("review #%d (id %d): both a book and a work are set", i+1, r.ID))
+		} else if ref != (WorkRef{}) && WorkTitle(books, works, ref) == "" {
+			errs = append(errs, fmt.Errorf("review #%d (id %d): %s", i+1, r.ID, WorkLabel(books, works, ref)))
+		}
+		key := chapterKey{ref, ChapterPointOf(r)}
+		if chapters[key] {
+			errs = append(errs, fmt.Errorf("review #%d (id %d): %s of %s is reviewed twice",
+				i+1, r.ID, FormatChapter(r), WorkLabel(books, works, ref)))
+		}
+		chapters[key] = true
+		if err := validateChapter(r); err != nil {
+			errs = append(errs, fmt.Errorf("review #%d (id %d): %w", i+1, r.ID, err))
+		}
+
+		if err := checkRating(scale, r.Rating, false); err != nil {
+			errs = append(errs, fmt.Errorf("review #%d (%s): %w", i+1, FormatChapter(r), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateLinks checks that every link has a title and a usable URL.
+func validateLinks(entry string, links []models.ItemLink) []error {
+	var errs []error
+	for j, link := range links {
+		if strings.TrimSpace(link.Title) == "" {
+			errs = append(errs, fmt.Errorf("%s: link #%d: empty title", entry, j+1))
+		}
+		if err := ValidateURL(link.URL); err != nil {
+			errs = append(errs, fmt.Errorf("%s: link #%d: %w", entry, j+1, err))
+		}
+	}
+	return errs
+}
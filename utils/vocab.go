@@ -0,0 +1,216 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"utilodactyl/models"
+)
+
+const (
+	BookVocabularyFile = "books.vocab.json"
+	GameVocabularyFile = "games.vocab.json"
+)
+
+// LoadVocabulary reads and validates a vocabulary file. A missing file is an
+// empty vocabulary, which leaves genres and tags as they are typed.
+func LoadVocabulary(fileName string) (models.Vocabulary, error) {
+	var vocab models.Vocabulary
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return vocab, nil
+		}
+		return vocab, fmt.Errorf("failed to read %s: %w", fileName, err)
+	}
+	if err := json.Unmarshal(data, &vocab); err != nil {
+		return vocab, fmt.Errorf("failed to unmarshal %s: %w", fileName, err)
+	}
+	if err := ValidateVocabulary(vocab); err != nil {
+		return vocab, fmt.Errorf("invalid %s: %w", fileName, err)
+	}
+	return vocab, nil
+}
+
+func SaveVocabulary(fileName string, vocab models.Vocabulary) error {
+	data, err := json.MarshalIndent(vocab, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+	if err := os.WriteFile(fileName, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", fileName, err)
+	}
+	return nil
+}
+
+// ValidateVocabulary checks that every name and alias is used once, that
+// parents exist and that the hierarchy has no cycles.
+func ValidateVocabulary(vocab models.Vocabulary) error {
+	return errors.Join(
+		validateTerms("genre", vocab.Genres),
+		validateTerms("tag", vocab.Tags),
+	)
+}
+
+func validateTerms(noun string, terms []models.VocabTerm) error {
+	var errs []error
+	owner := make(map[string]string)
+	claim := func(spelling, name string) {
+		key := strings.ToLower(strings.TrimSpace(spelling))
+		if prev, ok := owner[key]; ok {
+			errs = append(errs, fmt.Errorf("%s %q is used by both %q and %q", noun, spelling, prev, name))
+			return
+		}
+		owner[key] = name
+	}
+
+	for _, t := range terms {
+		if strings.TrimSpace(t.Name) == "" {
+			errs = append(errs, fmt.Errorf("%s with empty name", noun))
+			continue
+		}
+		claim(t.Name, t.Name)
+		for _, alias := range t.Aliases {
+			claim(alias, t.Name)
+		}
+	}
+
+	parents := termParents(terms)
+	for _, t := range terms {
+		if t.Parent == "" {
+			continue
+		}
+		if _, ok := parents[strings.ToLower(t.Parent)]; !ok {
+			errs = append(errs, fmt.Errorf("%s %q has unknown parent %q", noun, t.Name, t.Parent))
+			continue
+		}
+		if len(ancestors(parents, t.Name)) > len(terms) {
+			errs = append(errs, fmt.Errorf("%s %q is its own ancestor", noun, t.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// termParents maps each lowercased term name to its parent.
+func termParents(terms []models.VocabTerm) map[string]string {
+	parents := make(map[string]string, len(terms))
+	for _, t := range terms {
+		parents[strings.ToLower(t.Name)] = t.Parent
+	}
+	return parents
+}
+
+// ancestors lists the parents of name, nearest first. On a cycle it stops once
+// it has gone round more times than there are terms.
+func ancestors(parents map[string]string, name string) []string {
+	var result []string
+	for p := parents[strings.ToLower(name)]; p != "" && len(result) <= len(parents); p = parents[strings.ToLower(p)] {
+		result = append(result, p)
+	}
+	return result
+}
+
+// TermNames returns the canonical names of terms.
+func TermNames(terms []models.VocabTerm) []string {
+	names := make([]string, len(terms))
+	for i, t := range terms {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// CanonicalTerm returns the canonical spelling of value if it is a term or an
+// alias of one, ignoring case.
+func CanonicalTerm(terms []models.VocabTerm, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	for _, t := range terms {
+		if strings.EqualFold(t.Name, value) {
+			return t.Name, true
+		}
+		for _, alias := range t.Aliases {
+			if strings.EqualFold(alias, value) {
+				return t.Name, true
+			}
+		}
+	}
+	return value, false
+}
+
+// NormalizeTerms replaces aliases in values with their canonical terms and
+// drops the duplicates that leaves.
+func NormalizeTerms(terms []models.VocabTerm, values []string) []string {
+	normalized := make([]string, len(values))
+	for i, v := range values {
+		normalized[i], _ = CanonicalTerm(terms, v)
+	}
+	return MergeStrings(normalized)
+}
+
+// ExpandParents adds the ancestors of every value, after the values
+// themselves, so that "cyberpunk" is also published as "sci-fi".
+func ExpandParents(terms []models.VocabTerm, values []string) []string {
+	parents := termParents(terms)
+	expanded := NormalizeTerms(terms, values)
+	for _, v := range expanded {
+		expanded = MergeStrings(expanded, ancestors(parents, v))
+	}
+	return expanded
+}
+
+// RenameTerms applies a tag manager change to a vocabulary: the terms in from
+// are removed, and when they are renamed or merged into a single term their
+// spellings become aliases of it, so that they keep being recognised.
+func RenameTerms(terms []models.VocabTerm, from, to []string) []models.VocabTerm {
+	var target string
+	if len(to) == 1 {
+		target = to[0]
+	}
+
+	removed := make(map[string]bool, len(from))
+	var aliases []string
+	for _, f := range from {
+		removed[strings.ToLower(f)] = true
+		if !strings.EqualFold(f, target) {
+			aliases = append(aliases, f)
+		}
+	}
+
+	var result []models.VocabTerm
+	var parent string
+	known := false
+	for _, t := range terms {
+		if !removed[strings.ToLower(t.Name)] {
+			result = append(result, t)
+			continue
+		}
+		known = true
+		aliases = append(aliases, t.Aliases...)
+		if parent == "" {
+			parent = t.Parent
+		}
+	}
+
+	for i := range result {
+		if removed[strings.ToLower(result[i].Parent)] {
+			result[i].Parent = target
+		}
+	}
+	if target == "" {
+		return result
+	}
+
+	for i := range result {
+		if strings.EqualFold(result[i].Name, target) {
+			result[i].Aliases = MergeStrings(result[i].Aliases, aliases)
+			return result
+		}
+	}
+	// Only terms the vocabulary already knew about are carried over, so that
+	// cleaning up a collection without a vocabulary does not start one.
+	if !known {
+		return result
+	}
+	return append(result, models.VocabTerm{Name: target, Aliases: MergeStrings(aliases), Parent: parent})
+}
@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"utilodactyl/models"
+)
+
+// Severities lists the content-warning severities, mildest first.
+var Severities = []models.Severity{models.SeverityMild, models.SeverityModerate, models.SeveritySevere}
+
+// DefaultWarningCategories is the content-warning taxonomy used unless
+// utilodactyl.json configures another for the collection.
+var DefaultWarningCategories = []models.WarningCategory{
+	{Name: "violence", Explicit: models.SeveritySevere},
+	{Name: "sexual content", Explicit: models.SeverityModerate},
+	{Name: "self-harm", Explicit: models.SeveritySevere},
+	{Name: "abuse", Explicit: models.SeveritySevere},
+	{Name: "substance use"},
+	{Name: "strong language"},
+}
+
+// BookWarnings returns the content-warning taxonomy for books.
+func BookWarnings() ([]models.WarningCategory, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return warningsOrDefault(cfg.Books)
+}
+
+// GameWarnings returns the content-warning taxonomy for games.
+func GameWarnings() ([]models.WarningCategory, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return warningsOrDefault(cfg.Games)
+}
+
+func warningsOrDefault(cfg models.CollectionConfig) ([]models.WarningCategory, error) {
+	if err := checkSeverity(cfg.MaxWarning, true); err != nil {
+		return nil, fmt.Errorf("invalid maxWarning in %s: %w", ConfigFile, err)
+	}
+	if len(cfg.ContentWarnings) == 0 {
+		return DefaultWarningCategories, nil
+	}
+	if err := validateWarningConfig(cfg.ContentWarnings); err != nil {
+		return nil, fmt.Errorf("invalid content warnings in %s: %w", ConfigFile, err)
+	}
+	return cfg.ContentWarnings, nil
+}
+
+func validateWarningConfig(categories []models.WarningCategory) error {
+	var errs []error
+	seen := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		if strings.TrimSpace(c.Name) == "" {
+			errs = append(errs, errors.New("content warning with empty name"))
+		}
+		if seen[c.Name] {
+			errs = append(errs, fmt.Errorf("duplicate content warning %q", c.Name))
+		}
+		seen[c.Name] = true
+		if err := checkSeverity(c.Explicit, true); err != nil {
+			errs = append(errs, fmt.Errorf("content warning %q: %w", c.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// severityRank orders severities from 1 for the mildest; unknown severities
+// rank 0.
+func severityRank(s models.Severity) int {
+	return slices.Index(Severities, s) + 1
+}
+
+func checkSeverity(s models.Severity, optional bool) error {
+	if (s == "" && optional) || severityRank(s) > 0 {
+		return nil
+	}
+	return fmt.Errorf("unknown severity %q (expected %s)", s, joinSeverities())
+}
+
+func joinSeverities() string {
+	names := make([]string, len(Severities))
+	for i, s := range Severities {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+// FindWarning returns the severity an entry's warnings give a category.
+func FindWarning(warnings []models.ContentWarning, category string) (models.Severity, bool) {
+	i := slices.IndexFunc(warnings, func(w models.ContentWarning) bool { return w.Category == category })
+	if i < 0 {
+		return "", false
+	}
+	return warnings[i].Severity, true
+}
+
+// DeriveExplicit works out whether an entry is explicit from its content
+// warnings. Entries without warnings keep the explicit flag they were given,
+// as entries did before warnings existed.
+func DeriveExplicit(taxonomy []models.WarningCategory, warnings []models.ContentWarning, explicit bool) bool {
+	if len(warnings) == 0 {
+		return explicit
+	}
+	for _, w := range warnings {
+		i := slices.IndexFunc(taxonomy, func(c models.WarningCategory) bool { return c.Name == w.Category })
+		if i >= 0 && taxonomy[i].Explicit != "" && severityRank(w.Severity) >= severityRank(taxonomy[i].Explicit) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExceedsWarning reports whether any warning is stronger than limit. No
+// limit lets every entry through.
+func ExceedsWarning(warnings []models.ContentWarning, limit models.Severity) bool {
+	if limit == "" {
+		return false
+	}
+	return slices.ContainsFunc(warnings, func(w models.ContentWarning) bool {
+		return severityRank(w.Severity) > severityRank(limit)
+	})
+}
+
+// FormatWarnings renders content warnings on one line, e.g. "violence
+// (severe), strong language (mild)".
+func FormatWarnings(warnings []models.ContentWarning) string {
+	parts := make([]string, len(warnings))
+	for i, w := range warnings {
+		parts[i] = fmt.Sprintf("%s (%s)", w.Category, w.Severity)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// validateWarnings checks that an entry's warnings use the taxonomy's
+// categories, each once, at a known severity.
+func validateWarnings(taxonomy []models.WarningCategory, warnings []models.ContentWarning) error {
+	var errs []error
+	seen := make(map[string]bool, len(warnings))
+	for _, w := range warnings {
+		if !slices.ContainsFunc(taxonomy, func(c models.WarningCategory) bool { return c.Name == w.Category }) {
+			errs = append(errs, fmt.Errorf("unknown content warning %q", w.Category))
+		}
+		if seen[w.Category] {
+			errs = append(errs, fmt.Errorf("content warning %q given twice", w.Category))
+		}
+		seen[w.Category] = true
+		if err := checkSeverity(w.Severity, false); err != nil {
+			errs = append(errs, fmt.Errorf("content warning %q: %w", w.Category, err))
+		}
+	}
+	return errors.Join(errs...)
+}
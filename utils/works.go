@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"utilodactyl/models"
+)
+
+// WorksFile holds the serials and webcomics reviewed chapter by chapter that
+// are not in the book collection.
+const WorksFile = "works.json"
+
+// WorkKinds lists the kinds of work outside the book collection.
+var WorkKinds = []models.WorkKind{models.WorkSerial, models.WorkWebcomic}
+
+// WorkRef identifies the work a review is for: a book or an entry of the
+// works list. The zero WorkRef stands for reviews that name no work.
+type WorkRef struct {
+	BookID uint32
+	WorkID uint32
+}
+
+// ReviewWork returns the work a review is for.
+func ReviewWork(r models.Review) WorkRef {
+	return WorkRef{BookID: r.BookID, WorkID: r.WorkID}
+}
+
+// SetReviewWork points a review at a work.
+func SetReviewWork(r *models.Review, ref WorkRef) {
+	r.BookID, r.WorkID = ref.BookID, ref.WorkID
+}
+
+func LoadWorks() ([]models.Work, error) {
+	return readJSONFile[models.Work](WorksFile)
+}
+
+func SaveWorks(works []models.Work) error {
+	return writeJSONFile(WorksFile, works)
+}
+
+// NextWorkID is the ID a new entry of the works list should get.
+func NextWorkID(works []models.Work) uint32 {
+	var maxID uint32
+	for _, w := range works {
+		maxID = max(maxID, w.ID)
+	}
+	return maxID + 1
+}
+
+// WorkTitle names the work a reference points at, or "" if it points at
+// nothing known.
+func WorkTitle(books []models.Book, works []models.Work, ref WorkRef) string {
+	switch {
+	case ref.BookID != 0:
+		for _, b := range books {
+			if b.ID == ref.BookID {
+				return b.Title
+			}
+		}
+	case ref.WorkID != 0:
+		for _, w := range works {
+			if w.ID == ref.WorkID {
+				return w.Title
+			}
+		}
+	}
+	return ""
+}
+
+// WorkLabel names a work for display, falling back to "No work" for reviews
+// that name none.
+func WorkLabel(books []models.Book, works []models.Work, ref WorkRef) string {
+	if title := WorkTitle(books, works, ref); title != "" {
+		return title
+	}
+	if ref == (WorkRef{}) {
+		return "No work"
+	}
+	return fmt.Sprintf("Missing work %+v", ref)
+}
+
+// ReviewLabel describes a review in pickers.
+func ReviewLabel(books []models.Book, works []models.Work, r models.Review) string {
+	return joinLabel(WorkLabel(books, works, ReviewWork(r)), FormatChapter(r), r.Description)
+}
+
+// NextChapter returns where the next review of a work most likely goes: the
+// volume of its last reviewed chapter, and the numbered chapter after the
+// highest one so far.
+func NextChapter(reviews []models.Review, ref WorkRef) models.Review {
+	next := models.Review{Chapter: 1}
+	var last *models.Review
+	for i, r := range reviews {
+		if ReviewWork(r) != ref {
+			continue
+		}
+		if last == nil || CompareChapters(r, *last) > 0 {
+			last = &reviews[i]
+		}
+		if r.Label == "" {
+			next.Chapter = max(next.Chapter, math.Floor(r.Chapter)+1)
+		}
+	}
+	if last != nil {
+		next.Volume = last.Volume
+	}
+	return next
+}
+
+// ChapterTaken reports whether a review other than r already covers the
+// chapter of r's work that r is for.
+func ChapterTaken(reviews []models.Review, r models.Review) bool {
+	return slices.ContainsFunc(reviews, func(other models.Review) bool {
+		return other.ID != r.ID && ReviewWork(other) == ReviewWork(r) && SameChapter(other, r)
+	})
+}
+
+// GroupReviewsByWork orders reviews by work title, then in chapter order (see
+// CompareChapters), with reviews naming no work last.
+func GroupReviewsByWork(reviews []models.Review, books []models.Book, works []models.Work) []models.Review {
+	grouped := slices.Clone(reviews)
+	slices.SortStableFunc(grouped, func(a, b models.Review) int {
+		ra, rb := ReviewWork(a), ReviewWork(b)
+		if (ra == WorkRef{}) != (rb == WorkRef{}) {
+			if ra == (WorkRef{}) {
+				return 1
+			}
+			return -1
+		}
+		ta := strings.ToLower(WorkTitle(books, works, ra))
+		tb := strings.ToLower(WorkTitle(books, works, rb))
+		return cmp.Or(
+			cmp.Compare(ta, tb),
+			cmp.Compare(ra.BookID, rb.BookID),
+			cmp.Compare(ra.WorkID, rb.WorkID),
+			CompareChapters(a, b),
+		)
+	})
+	return grouped
+}
+
+// ValidateWorks checks the works list for duplicate IDs, empty titles and
+// unknown kinds.
+func ValidateWorks(works []models.Work) error {
+	var errs []error
+	seen := make(map[uint32]bool, len(works))
+	for i, w := range works {
+		if seen[w.ID] {
+			errs = append(errs, fmt.Errorf("work #%d: duplicate id %d", i+1, w.ID))
+		}
+		seen[w.ID] = true
+		if strings.TrimSpace(w.Title) == "" {
+			errs = append(errs, fmt.Errorf("work #%d (id %d): empty title", i+1, w.ID))
+		}
+		if !slices.Contains(WorkKinds, w.Kind) {
+			errs = append(errs, fmt.Errorf("work #%d (id %d): unknown kind %q", i+1, w.ID, w.Kind))
+		}
+		if w.URL != "" {
+			if err := ValidateURL(w.URL); err != nil {
+				errs = append(errs, fmt.Errorf("work #%d (id %d): %w", i+1, w.ID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}